@@ -1,6 +1,7 @@
 package meilisearch
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -19,11 +20,30 @@ type ClientConfig struct {
 
 	// Timeout is optional
 	Timeout time.Duration
+
+	// ValidateDocuments is optional, when enabled documents are checked
+	// before being added or updated and a *DocumentValidationError is
+	// returned instead of sending invalid documents.
+	ValidateDocuments bool
+
+	// JSONCodec is optional, it encodes and decodes request and response
+	// bodies. Default to DefaultJSONCodec, based on encoding/json.
+	JSONCodec JSONCodec
+
+	// SearchCache is optional, when set the responses of Index.Search,
+	// SearchInto, SearchRaw and SearchWith are cached as configured. Searches of an index are not cached while updates
+	// enqueued by the client are not seen finished.
+	SearchCache *SearchCacheConfig
 }
 
 // ClientInterface is interface for all Meilisearch client
 type ClientInterface interface {
 	Index(uid string) *Index
+	Alias(name string) (*Index, error)
+	SetAlias(ctx context.Context, name string, indexUID string) error
+	DeleteAlias(ctx context.Context, name string) error
+	Reindex(ctx context.Context, alias string, opts *ReindexOptions) (resp *Index, err error)
+	MultiSearch(ctx context.Context, queries []IndexQuery) (*MultiSearchResponse, error)
 	GetIndex(indexID string) (resp *Index, err error)
 	GetRawIndex(uid string) (resp map[string]interface{}, err error)
 	GetAllIndexes() (resp []*Index, err error)
@@ -47,8 +67,9 @@ var _ ClientInterface = &Client{}
 // NewFastHTTPCustomClient creates Meilisearch with custom fasthttp.Client
 func NewFastHTTPCustomClient(config ClientConfig, client *fasthttp.Client) *Client {
 	c := &Client{
-		config:     config,
-		httpClient: client,
+		config:      config,
+		httpClient:  client,
+		searchCache: newSearchCache(config.SearchCache),
 	}
 	return c
 }
@@ -59,8 +80,9 @@ func NewClient(config ClientConfig) *Client {
 		Name: "meilsearch-client",
 	}
 	c := &Client{
-		config:     config,
-		httpClient: client,
+		config:      config,
+		httpClient:  client,
+		searchCache: newSearchCache(config.SearchCache),
 	}
 	return c
 }
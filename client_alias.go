@@ -0,0 +1,227 @@
+package meilisearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// AliasesIndexUID is the uid of the index where the client stores its aliases.
+//
+// Meilisearch has no notion of index aliases, they are resolved client side
+// from the documents of this metadata index.
+const AliasesIndexUID = "meilisearch_go_aliases"
+
+// Alias is the document stored for every alias in the AliasesIndexUID index
+type Alias struct {
+	Name      string    `json:"alias"`
+	IndexUID  string    `json:"indexUid"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ReindexOptions configure Client.Reindex
+type ReindexOptions struct {
+
+	// PrimaryKey of the new generation index, it is optional
+	PrimaryKey string
+
+	// Settings applied to the new generation before loading documents, it is optional
+	Settings *Settings
+
+	// Load adds the documents to the new generation index and returns the
+	// updates to wait for before the alias is switched.
+	Load func(ctx context.Context, index *Index) ([]AsyncUpdateID, error)
+
+	// KeepGenerations is the number of previous generations kept after the
+	// alias is switched, the older ones are deleted. Default to 0.
+	KeepGenerations int
+
+	// WaitInterval is the interval between two update status checks.
+	// Default to 50ms.
+	WaitInterval time.Duration
+}
+
+// Alias resolves an alias to the index it currently points to.
+func (c *Client) Alias(name string) (*Index, error) {
+	alias := &Alias{}
+	if err := c.Index(AliasesIndexUID).GetDocument(name, alias); err != nil {
+		return nil, errors.Wrapf(err, "could not resolve alias %q", name)
+	}
+	return c.Index(alias.IndexUID), nil
+}
+
+// SetAlias points an alias to an index and waits for the change to be
+// processed. The switch is atomic as a single document holds the alias.
+func (c *Client) SetAlias(ctx context.Context, name string, indexUID string) error {
+	update, err := c.enqueueAlias(name, indexUID)
+	if err != nil {
+		return err
+	}
+	return c.Index(AliasesIndexUID).waitForUpdates(ctx, time.Millisecond*50, []AsyncUpdateID{*update})
+}
+
+// enqueueAlias enqueues the update pointing an alias to an index, the alias
+// is switched once it is processed
+func (c *Client) enqueueAlias(name string, indexUID string) (*AsyncUpdateID, error) {
+	return c.Index(AliasesIndexUID).AddDocuments([]Alias{
+		{Name: name, IndexUID: indexUID, UpdatedAt: time.Now().UTC()},
+	}, "alias")
+}
+
+// aliasSwitchFailed reports whether the alias update is known to have failed
+// and the alias does not point to indexUID. It is false when this cannot be
+// told, for an update still enqueued for instance.
+func (c *Client) aliasSwitchFailed(name string, indexUID string, update *AsyncUpdateID) bool {
+	status, err := c.Index(AliasesIndexUID).GetUpdateStatus(update.UpdateID)
+	if err != nil || status.Status != UpdateStatusFailed {
+		return false
+	}
+	alias := &Alias{}
+	if err := c.Index(AliasesIndexUID).GetDocument(name, alias); err != nil {
+		// Without a document the alias points to no index
+		apiErr, ok := err.(*Error)
+		return ok && apiErr.StatusCode == http.StatusNotFound
+	}
+	return alias.IndexUID != indexUID
+}
+
+// DeleteAlias removes an alias, the index it points to is kept.
+func (c *Client) DeleteAlias(ctx context.Context, name string) error {
+	aliases := c.Index(AliasesIndexUID)
+	update, err := aliases.DeleteDocument(name)
+	if err != nil {
+		return err
+	}
+	return aliases.waitForUpdates(ctx, time.Millisecond*50, []AsyncUpdateID{*update})
+}
+
+// Reindex builds a new generation of an aliased index without downtime.
+//
+// A new index named "<alias>_generation_<timestamp>" is created, the settings are
+// applied and the documents loaded with opts.Load. Once every update has been
+// processed the alias is switched to the new generation and the previous
+// generations, except the opts.KeepGenerations most recent ones, are deleted.
+// If anything fails before the switch the new generation is deleted and the
+// alias is left untouched. Once the switch is enqueued the new generation is
+// only deleted if the switch is known to have failed: when ctx is canceled
+// while waiting for it, the alias may still be switched and the new
+// generation is kept.
+func (c *Client) Reindex(ctx context.Context, alias string, opts *ReindexOptions) (resp *Index, err error) {
+	if opts == nil || opts.Load == nil {
+		return nil, fmt.Errorf("a Load function is required to reindex %q", alias)
+	}
+	interval := opts.WaitInterval
+	if interval <= 0 {
+		interval = time.Millisecond * 50
+	}
+
+	uid := generationUID(alias, time.Now().UnixNano()/int64(time.Millisecond))
+	resp, err = c.CreateIndex(&IndexConfig{
+		Uid:        uid,
+		PrimaryKey: opts.PrimaryKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.loadGeneration(ctx, resp, interval, opts); err != nil {
+		_, _ = c.DeleteIndexIfExists(uid)
+		return nil, err
+	}
+
+	update, err := c.enqueueAlias(alias, uid)
+	if err != nil {
+		_, _ = c.DeleteIndexIfExists(uid)
+		return nil, err
+	}
+	if err := c.Index(AliasesIndexUID).waitForUpdates(ctx, interval, []AsyncUpdateID{*update}); err != nil {
+		if c.aliasSwitchFailed(alias, uid, update) {
+			_, _ = c.DeleteIndexIfExists(uid)
+		}
+		return nil, err
+	}
+
+	if err := c.deleteOldGenerations(alias, uid, opts.KeepGenerations); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+func (c *Client) loadGeneration(ctx context.Context, index *Index, interval time.Duration, opts *ReindexOptions) error {
+	if opts.Settings != nil {
+		update, err := index.UpdateSettings(opts.Settings)
+		if err != nil {
+			return err
+		}
+		if err := index.waitForUpdates(ctx, interval, []AsyncUpdateID{*update}); err != nil {
+			return err
+		}
+	}
+
+	updates, err := opts.Load(ctx, index)
+	if err != nil {
+		return err
+	}
+	return index.waitForUpdates(ctx, interval, updates)
+}
+
+// generationPrefix prefixes the uid of the generations created by Reindex,
+// so indexes named after the alias by users are never mistaken for them
+const generationPrefix = "_generation_"
+
+func generationUID(alias string, generation int64) string {
+	return alias + generationPrefix + strconv.FormatInt(generation, 10)
+}
+
+// parseGeneration returns the generation of an index created by Reindex for
+// alias, false for any other index
+func parseGeneration(alias string, uid string) (int64, bool) {
+	if !strings.HasPrefix(uid, alias+generationPrefix) {
+		return 0, false
+	}
+	generation, err := strconv.ParseInt(strings.TrimPrefix(uid, alias+generationPrefix), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return generation, true
+}
+
+// deleteOldGenerations deletes the generations of an alias older than current,
+// except the keep most recent ones. Newer generations, being built by another
+// Reindex, are kept.
+func (c *Client) deleteOldGenerations(alias string, current string, keep int) error {
+	currentGeneration, ok := parseGeneration(alias, current)
+	if !ok {
+		return fmt.Errorf("%q is not a generation of alias %q", current, alias)
+	}
+	indexes, err := c.GetAllIndexes()
+	if err != nil {
+		return err
+	}
+
+	var generations []int64
+	for _, index := range indexes {
+		generation, ok := parseGeneration(alias, index.UID)
+		if !ok || generation >= currentGeneration {
+			continue
+		}
+		generations = append(generations, generation)
+	}
+	sort.Slice(generations, func(a, b int) bool { return generations[a] > generations[b] })
+
+	for j, generation := range generations {
+		if j < keep {
+			continue
+		}
+		if _, err := c.DeleteIndexIfExists(generationUID(alias, generation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package meilisearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Alias(t *testing.T) {
+	c := defaultClient
+	t.Cleanup(cleanup(c))
+	SetUpBasicIndex()
+
+	_, err := c.Alias("books")
+	require.Error(t, err)
+
+	err = c.SetAlias(context.Background(), "books", "indexUID")
+	require.NoError(t, err)
+
+	gotResp, err := c.Alias("books")
+	require.NoError(t, err)
+	require.Equal(t, "indexUID", gotResp.UID)
+
+	err = c.DeleteAlias(context.Background(), "books")
+	require.NoError(t, err)
+
+	_, err = c.Alias("books")
+	require.Error(t, err)
+}
+
+func TestClient_Reindex(t *testing.T) {
+	type args struct {
+		client *Client
+		alias  string
+		opts   *ReindexOptions
+	}
+	load := func(ctx context.Context, index *Index) ([]AsyncUpdateID, error) {
+		return index.AddDocumentsInBatches([]docTestBooks{
+			{BookID: 123, Title: "Pride and Prejudice", Tag: "Romance", Year: 1813},
+			{BookID: 456, Title: "Le Petit Prince", Tag: "Tale", Year: 1943},
+			{BookID: 1, Title: "Alice In Wonderland", Tag: "Tale", Year: 1865},
+		}, 2)
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantGenerations int
+	}{
+		{
+			name: "TestClientReindex",
+			args: args{
+				client: defaultClient,
+				alias:  "books",
+				opts: &ReindexOptions{
+					PrimaryKey: "book_id",
+					Load:       load,
+				},
+			},
+			wantGenerations: 1,
+		},
+		{
+			name: "TestClientReindexWithSettingsAndKeepGenerations",
+			args: args{
+				client: customClient,
+				alias:  "books",
+				opts: &ReindexOptions{
+					PrimaryKey: "book_id",
+					Settings: &Settings{
+						FilterableAttributes: []string{"tag"},
+					},
+					Load:            load,
+					KeepGenerations: 1,
+				},
+			},
+			wantGenerations: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.args.client
+			t.Cleanup(cleanup(c))
+
+			// Indexes named after the alias but not created by Reindex are kept
+			_, err := c.CreateIndex(&IndexConfig{Uid: tt.args.alias + "_2024"})
+			require.NoError(t, err)
+
+			for j := 0; j < 3; j++ {
+				gotResp, err := c.Reindex(context.Background(), tt.args.alias, tt.args.opts)
+				require.NoError(t, err)
+
+				index, err := c.Alias(tt.args.alias)
+				require.NoError(t, err)
+				require.Equal(t, gotResp.UID, index.UID)
+
+				stats, err := index.GetStats()
+				require.NoError(t, err)
+				require.Equal(t, int64(3), stats.NumberOfDocuments)
+			}
+
+			indexes, err := c.GetAllIndexes()
+			require.NoError(t, err)
+			generations := 0
+			userIndex := false
+			for _, index := range indexes {
+				if _, ok := parseGeneration(tt.args.alias, index.UID); ok {
+					generations++
+				}
+				userIndex = userIndex || index.UID == tt.args.alias+"_2024"
+			}
+			require.Equal(t, tt.wantGenerations, generations)
+			require.True(t, userIndex)
+		})
+	}
+}
+
+func Test_parseGeneration(t *testing.T) {
+	generation, ok := parseGeneration("books", generationUID("books", 1634567890123))
+	require.True(t, ok)
+	require.Equal(t, int64(1634567890123), generation)
+
+	for _, uid := range []string{"books_2024", "books_generation_", "books_generation_x", "movies_generation_1", "books"} {
+		_, ok := parseGeneration("books", uid)
+		require.False(t, ok, uid)
+	}
+}
+
+func TestClient_deleteOldGenerations(t *testing.T) {
+	c := defaultClient
+	t.Cleanup(cleanup(c))
+
+	uids := []string{
+		generationUID("books", 1),
+		generationUID("books", 2),
+		generationUID("books", 3),
+		generationUID("books", 5),
+		"books_4",
+	}
+	for _, uid := range uids {
+		_, err := c.CreateIndex(&IndexConfig{Uid: uid})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, c.deleteOldGenerations("books", generationUID("books", 3), 1))
+
+	indexes, err := c.GetAllIndexes()
+	require.NoError(t, err)
+	var got []string
+	for _, index := range indexes {
+		got = append(got, index.UID)
+	}
+	require.ElementsMatch(t, []string{
+		generationUID("books", 2),
+		generationUID("books", 3),
+		generationUID("books", 5),
+		"books_4",
+	}, got)
+}
+
+func TestClient_aliasSwitchFailed(t *testing.T) {
+	c := defaultClient
+	t.Cleanup(cleanup(c))
+	SetUpBasicIndex()
+
+	// An alias update not waited for may still be applied, it is not failed
+	update, err := c.enqueueAlias("books", "indexUID")
+	require.NoError(t, err)
+	require.False(t, c.aliasSwitchFailed("books", "indexUID", update))
+
+	testWaitForPendingUpdate(t, c.Index(AliasesIndexUID), update)
+	require.False(t, c.aliasSwitchFailed("books", "indexUID", update))
+	gotResp, err := c.Alias("books")
+	require.NoError(t, err)
+	require.Equal(t, "indexUID", gotResp.UID)
+}
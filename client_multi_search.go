@@ -0,0 +1,177 @@
+package meilisearch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// maxConcurrentSearches is the maximum number of searches run at once by
+// MultiSearch
+const maxConcurrentSearches = 8
+
+// IndexQuery is the search of one index in a Client.MultiSearch
+type IndexQuery struct {
+
+	// IndexUID is the uid of the index searched
+	IndexUID string
+
+	// Query is the query string, it is ignored by placeholder searches
+	Query string
+
+	// Request holds the search parameters, it is optional
+	Request *SearchRequest
+
+	// Weight multiplies the score of the hits of this query when the results
+	// are merged. Default to 1.
+	Weight float64
+}
+
+// MultiSearchResponse holds the responses of a Client.MultiSearch, in the
+// order of the queries
+type MultiSearchResponse struct {
+	Results []IndexSearchResponse
+}
+
+// IndexSearchResponse is the response of one query of a Client.MultiSearch
+type IndexSearchResponse struct {
+	IndexUID string
+	Weight   float64
+	Response *SearchResponse
+}
+
+// MergedHit is a hit of MultiSearchResponse.Merge annotated with the index
+// it comes from
+type MergedHit struct {
+	IndexUID string
+	Hit      interface{}
+	Score    float64
+}
+
+// MultiSearch searches several indexes at once and returns a response per
+// query. The queries are sent as concurrent searches, at most
+// maxConcurrentSearches at a time.
+//
+// Requests of the client cannot be canceled, ctx only guards their start: no
+// search is sent once ctx is done, and MultiSearch then returns ctx.Err()
+// without waiting for the searches already sent.
+func (c *Client) MultiSearch(ctx context.Context, queries []IndexQuery) (*MultiSearchResponse, error) {
+	for _, query := range queries {
+		if query.IndexUID == "" {
+			return nil, fmt.Errorf("MultiSearch: every query needs an index uid")
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	results, err := c.concurrentSearch(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &MultiSearchResponse{Results: make([]IndexSearchResponse, len(queries))}
+	for j, query := range queries {
+		weight := query.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		resp.Results[j] = IndexSearchResponse{
+			IndexUID: query.IndexUID,
+			Weight:   weight,
+			Response: results[j],
+		}
+	}
+	return resp, nil
+}
+
+// concurrentSearch runs every query as a search of its own, the first error
+// is returned. No search is started once ctx is done.
+func (c *Client) concurrentSearch(ctx context.Context, queries []IndexQuery) ([]*SearchResponse, error) {
+	results := make([]*SearchResponse, len(queries))
+	errs := make([]error, len(queries))
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	go func() {
+		// Searches are started while a slot is free, until ctx is done
+		defer func() {
+			wg.Wait()
+			close(done)
+		}()
+		slots := make(chan struct{}, maxConcurrentSearches)
+		for j := range queries {
+			select {
+			case <-ctx.Done():
+				return
+			case slots <- struct{}{}:
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			wg.Add(1)
+			go func(j int) {
+				defer func() {
+					<-slots
+					wg.Done()
+				}()
+				query := queries[j]
+				results[j], errs[j] = c.Index(query.IndexUID).Search(query.Query, query.searchRequest())
+			}(j)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-done:
+	}
+	if err := ctx.Err(); err != nil {
+		// Some queries may not have been searched
+		return nil, err
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+func (q IndexQuery) searchRequest() *SearchRequest {
+	if q.Request == nil {
+		return &SearchRequest{}
+	}
+	return q.Request
+}
+
+// Merge returns the hits of every response in a single list, best first,
+// of at most limit hits. A limit of 0 returns every hit.
+//
+// Meilisearch does not expose relevancy scores, a hit is scored from its
+// rank in the results of its index: weight / (rank + 1). Hits with the same
+// score keep the order of the queries.
+func (r *MultiSearchResponse) Merge(limit int) []MergedHit {
+	var hits []MergedHit
+	for _, result := range r.Results {
+		if result.Response == nil {
+			continue
+		}
+		for rank, hit := range result.Response.Hits {
+			hits = append(hits, MergedHit{
+				IndexUID: result.IndexUID,
+				Hit:      hit,
+				Score:    result.Weight / float64(result.Response.Offset+int64(rank)+1),
+			})
+		}
+	}
+	sort.SliceStable(hits, func(a, b int) bool {
+		return hits[a].Score > hits[b].Score
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
@@ -0,0 +1,130 @@
+package meilisearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiSearchResponse_Merge(t *testing.T) {
+	resp := &MultiSearchResponse{
+		Results: []IndexSearchResponse{
+			{
+				IndexUID: "products",
+				Weight:   1,
+				Response: &SearchResponse{Hits: []interface{}{"p1", "p2", "p3"}},
+			},
+			{
+				IndexUID: "articles",
+				Weight:   2,
+				Response: &SearchResponse{Hits: []interface{}{"a1", "a2"}},
+			},
+			{
+				IndexUID: "users",
+				Weight:   1,
+				Response: &SearchResponse{Hits: []interface{}{"u1"}, Offset: 1},
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []MergedHit
+	}{
+		{
+			name:  "TestMultiSearchMergeAll",
+			limit: 0,
+			want: []MergedHit{
+				{IndexUID: "articles", Hit: "a1", Score: 2},
+				{IndexUID: "products", Hit: "p1", Score: 1},
+				{IndexUID: "articles", Hit: "a2", Score: 1},
+				{IndexUID: "products", Hit: "p2", Score: 0.5},
+				{IndexUID: "users", Hit: "u1", Score: 0.5},
+				{IndexUID: "products", Hit: "p3", Score: 1.0 / 3},
+			},
+		},
+		{
+			name:  "TestMultiSearchMergeWithLimit",
+			limit: 2,
+			want: []MergedHit{
+				{IndexUID: "articles", Hit: "a1", Score: 2},
+				{IndexUID: "products", Hit: "p1", Score: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, resp.Merge(tt.limit))
+		})
+	}
+}
+
+func TestClient_MultiSearch(t *testing.T) {
+	SetUpBasicIndex()
+	c := defaultClient
+	t.Cleanup(cleanup(c))
+
+	movies := c.Index("movies")
+	update, err := movies.AddDocuments([]docTest{
+		{ID: "1", Name: "The Little Prince"},
+		{ID: "2", Name: "Prince of Persia"},
+		{ID: "3", Name: "Casablanca"},
+	})
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, movies, update)
+
+	resp, err := c.MultiSearch(context.Background(), []IndexQuery{
+		{IndexUID: "indexUID", Query: "prince"},
+		{IndexUID: "movies", Query: "prince", Request: &SearchRequest{Limit: 1}, Weight: 3},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+	require.Equal(t, "indexUID", resp.Results[0].IndexUID)
+	require.Equal(t, float64(1), resp.Results[0].Weight)
+	require.Len(t, resp.Results[0].Response.Hits, 2)
+	require.Equal(t, "movies", resp.Results[1].IndexUID)
+	require.Len(t, resp.Results[1].Response.Hits, 1)
+
+	merged := resp.Merge(0)
+	require.Len(t, merged, 3)
+	require.Equal(t, "movies", merged[0].IndexUID)
+
+	_, err = c.MultiSearch(context.Background(), []IndexQuery{
+		{IndexUID: "indexUID", Query: "prince"},
+		{IndexUID: "unknown", Query: "prince"},
+	})
+	require.Error(t, err)
+
+	_, err = c.MultiSearch(context.Background(), []IndexQuery{{Query: "prince"}})
+	require.Error(t, err)
+}
+
+func TestClient_MultiSearchManyQueries(t *testing.T) {
+	SetUpBasicIndex()
+	c := defaultClient
+	t.Cleanup(cleanup(c))
+
+	queries := make([]IndexQuery, 3*maxConcurrentSearches)
+	for j := range queries {
+		queries[j] = IndexQuery{IndexUID: "indexUID", Query: "prince"}
+	}
+	resp, err := c.MultiSearch(context.Background(), queries)
+	require.NoError(t, err)
+	require.Len(t, resp.Results, len(queries))
+	for _, result := range resp.Results {
+		require.Len(t, result.Response.Hits, 2)
+	}
+}
+
+func TestClient_concurrentSearchCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queries := make([]IndexQuery, 3*maxConcurrentSearches)
+	for j := range queries {
+		queries[j] = IndexQuery{IndexUID: "indexUID", Query: "prince"}
+	}
+	_, err := defaultClient.concurrentSearch(ctx, queries)
+	require.Equal(t, context.Canceled, err)
+}
@@ -8,8 +8,6 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/valyala/fasthttp"
-
-	"encoding/json"
 )
 
 const (
@@ -62,6 +60,7 @@ func (c *Client) executeRequest(req internalRequest) error {
 	if err != nil {
 		return err
 	}
+	c.invalidateSearchCacheAfter(&req)
 	return nil
 }
 
@@ -112,15 +111,7 @@ func (c *Client) sendRequest(req *internalRequest, internalError *Error, respons
 			request.SetBodyStream(reader, -1)
 		} else {
 			// Otherwise convert it to JSON
-			var (
-				data []byte
-				err  error
-			)
-			if marshaler, ok := rawRequest.(json.Marshaler); ok {
-				data, err = marshaler.MarshalJSON()
-			} else {
-				data, err = json.Marshal(rawRequest)
-			}
+			data, err := c.jsonCodec().Marshal(rawRequest)
 			internalError.RequestToString = string(data)
 			if err != nil {
 				return internalError.WithErrCode(ErrCodeMarshalRequest, err)
@@ -187,14 +178,7 @@ func (c *Client) handleResponse(req *internalRequest, response *fasthttp.Respons
 		rawBody := response.Body()
 		internalError.ResponseToString = string(rawBody)
 
-		var err error
-		if resp, ok := req.withResponse.(json.Unmarshaler); ok {
-			err = resp.UnmarshalJSON(rawBody)
-			req.withResponse = resp
-		} else {
-			err = json.Unmarshal(rawBody, req.withResponse)
-		}
-		if err != nil {
+		if err := c.jsonCodec().Unmarshal(rawBody, req.withResponse); err != nil {
 			return internalError.WithErrCode(ErrCodeResponseUnmarshalBody, err)
 		}
 	}
@@ -3,6 +3,7 @@ package meilisearch
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -148,6 +149,16 @@ func (e *Error) ErrorBody(body []byte) {
 	}
 }
 
+// isEndpointNotFound reports whether a request failed because the server does
+// not know the route, which happens with endpoints added by newer versions.
+func isEndpointNotFound(err error) bool {
+	apiErr, ok := err.(*Error)
+	if !ok || apiErr.ErrCode != MeilisearchApiErrorWithoutMessage {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusMethodNotAllowed
+}
+
 func namedSprintf(format string, params map[string]interface{}) string {
 	for key, val := range params {
 		format = strings.ReplaceAll(format, "${"+key+"}", fmt.Sprintf("%v", val))
@@ -0,0 +1,435 @@
+// Package filter builds Meilisearch filter expressions.
+//
+// Expressions render to the filter syntax of Meilisearch with every value
+// quoted and escaped, so user input can never change the structure of a
+// filter:
+//
+//	f := filter.And(
+//		filter.Eq("genre", "horror"),
+//		filter.Or(filter.Gt("year", 2000), filter.Gte("rating", 4)),
+//	)
+//	f.String() // genre = "horror" AND (year > 2000 OR rating >= 4)
+//
+// Expressions implement json.Marshaler and can be used directly as the Filter
+// of a meilisearch.SearchRequest. Parse turns a filter string back into an
+// Expression.
+//
+// Documentation: https://docs.meilisearch.com/reference/features/filtering_and_faceted_search.html
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Operator is a comparison operator of a Condition
+type Operator string
+
+const (
+	OpEqual          Operator = "="
+	OpNotEqual       Operator = "!="
+	OpGreater        Operator = ">"
+	OpGreaterOrEqual Operator = ">="
+	OpLower          Operator = "<"
+	OpLowerOrEqual   Operator = "<="
+)
+
+// Expression is a filter expression
+type Expression interface {
+	json.Marshaler
+
+	// String renders the expression with the Meilisearch filter syntax
+	String() string
+
+	// precedence of the expression, a sub-expression of lower precedence is
+	// rendered between parentheses
+	precedence() int
+}
+
+const (
+	precedenceOr = iota
+	precedenceAnd
+	precedenceNot
+	precedenceCondition
+)
+
+// Condition compares an attribute to a value
+type Condition struct {
+	Attribute string
+	Operator  Operator
+	Value     interface{}
+}
+
+// Range matches the values of an attribute between From and To included
+type Range struct {
+	Attribute string
+	From      interface{}
+	To        interface{}
+}
+
+// GeoRadiusExpression matches the documents whose _geo field is within
+// Distance meters of the point at Lat, Lng
+type GeoRadiusExpression struct {
+	Lat      float64
+	Lng      float64
+	Distance float64
+}
+
+// AndExpression matches the documents matching all its expressions. Nil
+// expressions are ignored and an empty AndExpression renders as an empty
+// string, so conditions can be added conditionally. It is encoded as JSON
+// null, which meilisearch.SearchRequest omits, so it does not filter.
+type AndExpression []Expression
+
+// OrExpression matches the documents matching any of its expressions. As
+// with AndExpression, nil expressions are ignored.
+type OrExpression []Expression
+
+// NotExpression matches the documents not matching its expression. It renders
+// as an empty string when its expression is nil or empty, so it is ignored
+// inside an AndExpression or an OrExpression.
+type NotExpression struct {
+	Expression Expression
+}
+
+// Eq is attribute = value
+func Eq(attribute string, value interface{}) Condition {
+	return Condition{Attribute: attribute, Operator: OpEqual, Value: value}
+}
+
+// Neq is attribute != value
+func Neq(attribute string, value interface{}) Condition {
+	return Condition{Attribute: attribute, Operator: OpNotEqual, Value: value}
+}
+
+// Gt is attribute > value
+func Gt(attribute string, value interface{}) Condition {
+	return Condition{Attribute: attribute, Operator: OpGreater, Value: value}
+}
+
+// Gte is attribute >= value
+func Gte(attribute string, value interface{}) Condition {
+	return Condition{Attribute: attribute, Operator: OpGreaterOrEqual, Value: value}
+}
+
+// Lt is attribute < value
+func Lt(attribute string, value interface{}) Condition {
+	return Condition{Attribute: attribute, Operator: OpLower, Value: value}
+}
+
+// Lte is attribute <= value
+func Lte(attribute string, value interface{}) Condition {
+	return Condition{Attribute: attribute, Operator: OpLowerOrEqual, Value: value}
+}
+
+// Between is attribute from TO to
+func Between(attribute string, from, to interface{}) Range {
+	return Range{Attribute: attribute, From: from, To: to}
+}
+
+// In matches the documents whose attribute equals any of values, it is
+// rendered as attribute = value1 OR attribute = value2.
+func In(attribute string, values ...interface{}) OrExpression {
+	or := make(OrExpression, len(values))
+	for j, value := range values {
+		or[j] = Eq(attribute, value)
+	}
+	return or
+}
+
+// And matches the documents matching all expressions
+func And(expressions ...Expression) AndExpression {
+	return AndExpression(expressions)
+}
+
+// Or matches the documents matching any of expressions
+func Or(expressions ...Expression) OrExpression {
+	return OrExpression(expressions)
+}
+
+// Not matches the documents not matching expression
+func Not(expression Expression) NotExpression {
+	return NotExpression{Expression: expression}
+}
+
+// GeoRadius matches the documents within distance meters of lat, lng
+func GeoRadius(lat, lng, distance float64) GeoRadiusExpression {
+	return GeoRadiusExpression{Lat: lat, Lng: lng, Distance: distance}
+}
+
+func (c Condition) String() string {
+	return Attribute(c.Attribute) + " " + string(c.Operator) + " " + Value(c.Value)
+}
+
+func (r Range) String() string {
+	return Attribute(r.Attribute) + " " + Value(r.From) + " TO " + Value(r.To)
+}
+
+func (g GeoRadiusExpression) String() string {
+	return fmt.Sprintf("_geoRadius(%s, %s, %s)", formatFloat(g.Lat), formatFloat(g.Lng), formatFloat(g.Distance))
+}
+
+func (a AndExpression) String() string {
+	return join(a, " AND ", precedenceAnd)
+}
+
+func (o OrExpression) String() string {
+	return join(o, " OR ", precedenceOr)
+}
+
+func (n NotExpression) String() string {
+	if n.Expression == nil || n.Expression.String() == "" {
+		return ""
+	}
+	return "NOT " + operand(n.Expression, precedenceNot)
+}
+
+func (Condition) precedence() int           { return precedenceCondition }
+func (Range) precedence() int               { return precedenceCondition }
+func (GeoRadiusExpression) precedence() int { return precedenceCondition }
+func (NotExpression) precedence() int       { return precedenceNot }
+
+func (a AndExpression) precedence() int {
+	return compositePrecedence(a, precedenceAnd)
+}
+
+func (o OrExpression) precedence() int {
+	return compositePrecedence(o, precedenceOr)
+}
+
+// MarshalJSON encodes the expression as a JSON string, it fails when the
+// value is nil
+func (c Condition) MarshalJSON() ([]byte, error) { return marshal(c) }
+
+// MarshalJSON encodes the expression as a JSON string, it fails when a bound
+// is nil
+func (r Range) MarshalJSON() ([]byte, error) { return marshal(r) }
+
+// MarshalJSON encodes the expression as a JSON string
+func (g GeoRadiusExpression) MarshalJSON() ([]byte, error) { return marshal(g) }
+
+// MarshalJSON encodes the expression as a JSON string, or null when it is
+// empty. It fails when a value is nil.
+func (a AndExpression) MarshalJSON() ([]byte, error) { return marshal(a) }
+
+// MarshalJSON encodes the expression as a JSON string, or null when it is
+// empty. It fails when a value is nil.
+func (o OrExpression) MarshalJSON() ([]byte, error) { return marshal(o) }
+
+// MarshalJSON encodes the expression as a JSON string, or null when it is
+// empty. It fails when a value is nil.
+func (n NotExpression) MarshalJSON() ([]byte, error) { return marshal(n) }
+
+// Validate returns an error when expression compares an attribute to a nil
+// value, which Meilisearch filters cannot express. Such an expression renders
+// the value as null and fails to be encoded as JSON.
+func Validate(expression Expression) error {
+	switch e := expression.(type) {
+	case Condition:
+		if e.Value == nil {
+			return fmt.Errorf("filter: nil value for %s %s, Meilisearch filters cannot compare an attribute to null", Attribute(e.Attribute), e.Operator)
+		}
+	case Range:
+		if e.From == nil || e.To == nil {
+			return fmt.Errorf("filter: nil bound for the range of %s, Meilisearch filters cannot compare an attribute to null", Attribute(e.Attribute))
+		}
+	case AndExpression:
+		return validateAll(e)
+	case OrExpression:
+		return validateAll(e)
+	case NotExpression:
+		if e.Expression != nil {
+			return Validate(e.Expression)
+		}
+	}
+	return nil
+}
+
+func validateAll(expressions []Expression) error {
+	for _, e := range expressions {
+		if e == nil {
+			continue
+		}
+		if err := Validate(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Array renders expression with the array syntax of Meilisearch: the
+// elements of the outer array are joined with AND and the elements of inner
+// arrays with OR.
+//
+//	filter.Array(filter.And(filter.In("genre", "horror", "comedy"), filter.Gt("year", 2000)))
+//	// [["genre = \"horror\"", "genre = \"comedy\""], "year > 2000"]
+func Array(expression Expression) []interface{} {
+	and, ok := expression.(AndExpression)
+	if !ok {
+		and = AndExpression{expression}
+	}
+	and = members(and)
+	array := make([]interface{}, 0, len(and))
+	for _, e := range and {
+		if or, ok := e.(OrExpression); ok && len(members(or)) > 1 {
+			or = members(or)
+			strs := make([]string, len(or))
+			for j, member := range or {
+				strs[j] = member.String()
+			}
+			array = append(array, strs)
+			continue
+		}
+		array = append(array, e.String())
+	}
+	return array
+}
+
+// Attribute renders an attribute name, quoted when it is not made of
+// letters, digits, '_', '-' and '.' only.
+func Attribute(attribute string) string {
+	if attribute == "" || !isBare(attribute) || isKeyword(attribute) {
+		return quote(attribute)
+	}
+	return attribute
+}
+
+// Value renders a filter value. Numbers are rendered as is and any other
+// value as a quoted and escaped string. Meilisearch filters cannot compare to
+// null, a nil value renders as null and is rejected by Validate.
+func Value(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case int:
+		return strconv.FormatInt(int64(v), 10)
+	case int8:
+		return strconv.FormatInt(int64(v), 10)
+	case int16:
+		return strconv.FormatInt(int64(v), 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return formatFloat(v)
+	case json.Number:
+		if _, err := v.Float64(); err == nil {
+			return v.String()
+		}
+		return quote(v.String())
+	case string:
+		return quote(v)
+	case fmt.Stringer:
+		return quote(v.String())
+	default:
+		return quote(fmt.Sprint(v))
+	}
+}
+
+// marshal encodes a valid expression as a JSON string, or null when it is empty
+func marshal(expression Expression) ([]byte, error) {
+	if err := Validate(expression); err != nil {
+		return nil, err
+	}
+	s := expression.String()
+	if s == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// quote wraps s in double quotes, escaping backslashes and double quotes
+func quote(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	for _, r := range s {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func isBare(s string) bool {
+	for _, r := range s {
+		if !isBareRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isBareRune(r rune) bool {
+	return r == '_' || r == '-' || r == '.' ||
+		(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r > 0x7f && r != 0xa0
+}
+
+func isKeyword(s string) bool {
+	switch strings.ToUpper(s) {
+	case "AND", "OR", "NOT", "TO":
+		return true
+	}
+	return false
+}
+
+// members returns the expressions that are not nil or empty
+func members(expressions []Expression) []Expression {
+	kept := make([]Expression, 0, len(expressions))
+	for _, e := range expressions {
+		if e != nil && e.String() != "" {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+func compositePrecedence(expressions []Expression, precedence int) int {
+	switch kept := members(expressions); len(kept) {
+	case 0:
+		return precedenceCondition
+	case 1:
+		return kept[0].precedence()
+	default:
+		return precedence
+	}
+}
+
+func join(expressions []Expression, separator string, precedence int) string {
+	kept := members(expressions)
+	parts := make([]string, len(kept))
+	for j, e := range kept {
+		parts[j] = operand(e, precedence)
+	}
+	return strings.Join(parts, separator)
+}
+
+// operand renders e, between parentheses when its precedence is not higher
+// than the precedence of the expression it is part of
+func operand(e Expression, precedence int) string {
+	if e.precedence() <= precedence {
+		return "(" + e.String() + ")"
+	}
+	return e.String()
+}
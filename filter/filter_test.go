@@ -0,0 +1,158 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpression_String(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression Expression
+		want       string
+	}{
+		{
+			name:       "TestEqString",
+			expression: Eq("genre", "horror"),
+			want:       `genre = "horror"`,
+		},
+		{
+			name:       "TestEqEscaped",
+			expression: Eq("title", `Ain't "Nothing" \ OR 1 = 1`),
+			want:       `title = "Ain't \"Nothing\" \\ OR 1 = 1"`,
+		},
+		{
+			name:       "TestComparisons",
+			expression: And(Neq("id", 3), Gt("year", 2000), Gte("rating", 4.5), Lt("price", uint8(10)), Lte("stock", int64(-1))),
+			want:       `id != 3 AND year > 2000 AND rating >= 4.5 AND price < 10 AND stock <= -1`,
+		},
+		{
+			name:       "TestQuotedAttribute",
+			expression: Eq("release date", json.Number("1999")),
+			want:       `"release date" = 1999`,
+		},
+		{
+			name:       "TestKeywordAttribute",
+			expression: Eq("to", true),
+			want:       `"to" = "true"`,
+		},
+		{
+			name:       "TestBetween",
+			expression: Between("year", 1900, 2000),
+			want:       `year 1900 TO 2000`,
+		},
+		{
+			name:       "TestIn",
+			expression: In("genre", "horror", "comedy"),
+			want:       `genre = "horror" OR genre = "comedy"`,
+		},
+		{
+			name:       "TestPrecedence",
+			expression: And(Eq("genre", "horror"), Or(Gt("year", 2000), Gte("rating", 4))),
+			want:       `genre = "horror" AND (year > 2000 OR rating >= 4)`,
+		},
+		{
+			name:       "TestOrOfAnd",
+			expression: Or(And(Eq("a", 1), Eq("b", 2)), Eq("c", 3)),
+			want:       `a = 1 AND b = 2 OR c = 3`,
+		},
+		{
+			name:       "TestNot",
+			expression: And(Not(Eq("genre", "horror")), Not(In("tag", "a", "b"))),
+			want:       `NOT genre = "horror" AND NOT (tag = "a" OR tag = "b")`,
+		},
+		{
+			name:       "TestGeoRadius",
+			expression: And(GeoRadius(48.8566, 2.3522, 2000), Eq("type", "museum")),
+			want:       `_geoRadius(48.8566, 2.3522, 2000) AND type = "museum"`,
+		},
+		{
+			name:       "TestIgnoreEmptyExpressions",
+			expression: And(nil, Or(), Eq("genre", "horror"), And(Or(Gt("year", 2000)))),
+			want:       `genre = "horror" AND year > 2000`,
+		},
+		{
+			name:       "TestEmptyAnd",
+			expression: And(),
+			want:       ``,
+		},
+		{
+			name:       "TestNotOfEmpty",
+			expression: And(Eq("a", 1), Not(And()), Or(Not(nil))),
+			want:       `a = 1`,
+		},
+		{
+			name:       "TestNotOfNil",
+			expression: Not(nil),
+			want:       ``,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.expression.String())
+
+			data, err := json.Marshal(tt.expression)
+			require.NoError(t, err)
+			want, _ := json.Marshal(tt.want)
+			if tt.want == "" {
+				// Empty expressions do not filter
+				want = []byte("null")
+			}
+			require.Equal(t, string(want), string(data))
+		})
+	}
+}
+
+func TestArray(t *testing.T) {
+	got := Array(And(In("genre", "horror", "comedy"), Gt("year", 2000), Or(Eq("a", 1))))
+	require.Equal(t, []interface{}{
+		[]string{`genre = "horror"`, `genre = "comedy"`},
+		`year > 2000`,
+		`a = 1`,
+	}, got)
+
+	got = Array(Eq("genre", "horror"))
+	require.Equal(t, []interface{}{`genre = "horror"`}, got)
+}
+
+func TestValidate_Nil(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression Expression
+		wantErr    bool
+	}{
+		{name: "TestValidateCondition", expression: Eq("genre", "horror")},
+		{name: "TestValidateNilValue", expression: Eq("genre", nil), wantErr: true},
+		{name: "TestValidateNilBound", expression: Between("year", 1900, nil), wantErr: true},
+		{name: "TestValidateNestedNilValue", expression: And(Eq("a", 1), Or(Eq("b", 2), Not(Eq("c", nil)))), wantErr: true},
+		{name: "TestValidateNilExpressions", expression: And(nil, Not(nil), Or())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Never panics
+			_ = tt.expression.String()
+			err := Validate(tt.expression)
+			_, marshalErr := json.Marshal(tt.expression)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Error(t, marshalErr)
+			} else {
+				require.NoError(t, err)
+				require.NoError(t, marshalErr)
+			}
+		})
+	}
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	for _, f := range []string{`name = "inf"`, `name = "nan"`, `name = "0x10"`, `size = 1.5`} {
+		parsed, err := Parse(f)
+		require.NoError(t, err)
+		require.Equal(t, f, parsed.String())
+	}
+	parsed, err := Parse("name = inf")
+	require.NoError(t, err)
+	require.Equal(t, `name = "inf"`, parsed.String())
+}
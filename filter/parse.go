@@ -0,0 +1,352 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SyntaxError is returned by Parse for an invalid filter
+type SyntaxError struct {
+	// Offset is the byte offset of the error in the filter
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid filter at offset %d: %s", e.Offset, e.Msg)
+}
+
+// Parse parses a filter string into an Expression.
+//
+// Values are parsed as float64 when they are unquoted numbers, as strings
+// otherwise. AND has precedence over OR, and NOT over AND; keywords are case
+// insensitive.
+func Parse(s string) (Expression, error) {
+	p := &parser{s: s}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenEOF {
+		return And(), nil
+	}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.errorf("unexpected %s", p.tok)
+	}
+	return e, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenQuoted
+	tokenOperator
+	tokenOpen
+	tokenClose
+	tokenComma
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of filter"
+	case tokenQuoted:
+		return strconv.Quote(t.text)
+	default:
+		return "'" + t.text + "'"
+	}
+}
+
+// isKeyword reports whether the token is the given keyword
+func (t token) isKeyword(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+type parser struct {
+	s   string
+	pos int
+	tok token
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Offset: p.tok.offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+// next reads the next token into p.tok
+func (p *parser) next() error {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos == len(p.s) {
+		p.tok = token{kind: tokenEOF, offset: start}
+		return nil
+	}
+
+	switch c := p.s[p.pos]; c {
+	case '(':
+		p.pos++
+		p.tok = token{kind: tokenOpen, text: "(", offset: start}
+	case ')':
+		p.pos++
+		p.tok = token{kind: tokenClose, text: ")", offset: start}
+	case ',':
+		p.pos++
+		p.tok = token{kind: tokenComma, text: ",", offset: start}
+	case '=':
+		p.pos++
+		p.tok = token{kind: tokenOperator, text: "=", offset: start}
+	case '!', '>', '<':
+		p.pos++
+		if p.pos < len(p.s) && p.s[p.pos] == '=' {
+			p.pos++
+		} else if c == '!' {
+			return &SyntaxError{Offset: start, Msg: "expected '!='"}
+		}
+		p.tok = token{kind: tokenOperator, text: p.s[start:p.pos], offset: start}
+	case '"', '\'':
+		text, err := p.readQuoted(c)
+		if err != nil {
+			return err
+		}
+		p.tok = token{kind: tokenQuoted, text: text, offset: start}
+	default:
+		for p.pos < len(p.s) {
+			r, size := utf8.DecodeRuneInString(p.s[p.pos:])
+			if strings.ContainsRune(" \t\r\n()=!<>,\"'", r) {
+				break
+			}
+			p.pos += size
+		}
+		p.tok = token{kind: tokenWord, text: p.s[start:p.pos], offset: start}
+	}
+	return nil
+}
+
+// readQuoted reads a string quoted with quote, backslash escapes are decoded
+func (p *parser) readQuoted(quote byte) (string, error) {
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return b.String(), nil
+		case c == '\\' && p.pos+1 < len(p.s):
+			p.pos++
+			switch e := p.s[p.pos]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			default:
+				b.WriteByte(e)
+			}
+			p.pos++
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", &SyntaxError{Offset: start, Msg: "unterminated quoted string"}
+}
+
+func (p *parser) parseOr() (Expression, error) {
+	e, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	or := OrExpression{e}
+	for p.tok.isKeyword("OR") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		e, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, e)
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *parser) parseAnd() (Expression, error) {
+	e, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	and := AndExpression{e}
+	for p.tok.isKeyword("AND") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, e)
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+func (p *parser) parseNot() (Expression, error) {
+	if !p.tok.isKeyword("NOT") {
+		return p.parsePrimary()
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	e, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	return Not(e), nil
+}
+
+func (p *parser) parsePrimary() (Expression, error) {
+	switch {
+	case p.tok.kind == tokenOpen:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokenClose {
+			return nil, p.errorf("expected ')', got %s", p.tok)
+		}
+		return e, p.next()
+	case p.tok.kind == tokenWord && p.tok.text == "_geoRadius":
+		return p.parseGeoRadius()
+	default:
+		return p.parseCondition()
+	}
+}
+
+func (p *parser) parseCondition() (Expression, error) {
+	attribute, err := p.parseValue("an attribute")
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenOperator {
+		operator := Operator(p.tok.text)
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue("a value")
+		if err != nil {
+			return nil, err
+		}
+		return Condition{Attribute: fmt.Sprint(attribute), Operator: operator, Value: value}, nil
+	}
+
+	// attribute from TO to
+	from, err := p.parseValue("an operator")
+	if err != nil {
+		return nil, err
+	}
+	if !p.tok.isKeyword("TO") {
+		return nil, p.errorf("expected 'TO', got %s", p.tok)
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	to, err := p.parseValue("a value")
+	if err != nil {
+		return nil, err
+	}
+	return Range{Attribute: fmt.Sprint(attribute), From: from, To: to}, nil
+}
+
+// parseValue reads a word or a quoted string, unquoted numbers are returned
+// as float64
+func (p *parser) parseValue(expected string) (interface{}, error) {
+	tok := p.tok
+	switch {
+	case tok.kind == tokenQuoted:
+	case tok.kind == tokenWord && !isKeyword(tok.text):
+	default:
+		return nil, p.errorf("expected %s, got %s", expected, tok)
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if tok.kind == tokenWord {
+		if f, ok := parseNumber(tok.text); ok {
+			return f, nil
+		}
+	}
+	return tok.text, nil
+}
+
+// decimalLiteral is a finite decimal number, strconv.ParseFloat also accepts
+// inf, nan and hexadecimal numbers which are strings for Meilisearch
+var decimalLiteral = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// parseNumber parses a finite decimal literal
+func parseNumber(s string) (float64, bool) {
+	if !decimalLiteral.MatchString(s) {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil
+}
+
+func (p *parser) parseGeoRadius() (Expression, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenOpen {
+		return nil, p.errorf("expected '(', got %s", p.tok)
+	}
+	var args [3]float64
+	for j := range args {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokenWord {
+			return nil, p.errorf("expected a number, got %s", p.tok)
+		}
+		f, ok := parseNumber(p.tok.text)
+		if !ok {
+			return nil, p.errorf("expected a number, got %s", p.tok)
+		}
+		args[j] = f
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		want := tokenComma
+		if j == len(args)-1 {
+			want = tokenClose
+		}
+		if p.tok.kind != want {
+			return nil, p.errorf("_geoRadius expects 3 arguments, got %s", p.tok)
+		}
+	}
+	return GeoRadius(args[0], args[1], args[2]), p.next()
+}
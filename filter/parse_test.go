@@ -0,0 +1,100 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   Expression
+	}{
+		{
+			name:   "TestParseCondition",
+			filter: `genre = horror`,
+			want:   Eq("genre", "horror"),
+		},
+		{
+			name:   "TestParseQuoted",
+			filter: `title != 'Ain\'t "Nothing"'`,
+			want:   Neq("title", `Ain't "Nothing"`),
+		},
+		{
+			name:   "TestParseNumbers",
+			filter: `year>=2000 AND rating < -1.5`,
+			want:   And(Gte("year", float64(2000)), Lt("rating", -1.5)),
+		},
+		{
+			name:   "TestParseRange",
+			filter: `year 1900 to 2000`,
+			want:   Between("year", float64(1900), float64(2000)),
+		},
+		{
+			name:   "TestParsePrecedence",
+			filter: `a = 1 OR b = 2 AND NOT c = 3`,
+			want:   Or(Eq("a", float64(1)), And(Eq("b", float64(2)), Not(Eq("c", float64(3))))),
+		},
+		{
+			name:   "TestParseParentheses",
+			filter: `genre = "horror" AND (year > 2000 OR rating >= 4)`,
+			want:   And(Eq("genre", "horror"), Or(Gt("year", float64(2000)), Gte("rating", float64(4)))),
+		},
+		{
+			name:   "TestParseGeoRadius",
+			filter: `_geoRadius(48.8566, 2.3522, 2000) and not type = museum`,
+			want:   And(GeoRadius(48.8566, 2.3522, 2000), Not(Eq("type", "museum"))),
+		},
+		{
+			name:   "TestParseEmpty",
+			filter: "  ",
+			want:   And(),
+		},
+		{
+			name:   "TestParseNonDecimalNumbers",
+			filter: `name = inf OR name = NaN OR name = infinity OR name = 0x1p-2 OR size = 1e3`,
+			want: Or(Eq("name", "inf"), Eq("name", "NaN"), Eq("name", "infinity"), Eq("name", "0x1p-2"),
+				Eq("size", float64(1000))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.filter)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+
+			// Rendering the parsed expression gives back the same expression
+			again, err := Parse(got.String())
+			require.NoError(t, err)
+			require.Equal(t, got, again)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     string
+		wantOffset int
+	}{
+		{name: "TestParseMissingValue", filter: `genre =`, wantOffset: 7},
+		{name: "TestParseMissingOperator", filter: `genre horror`, wantOffset: 12},
+		{name: "TestParseUnterminatedQuote", filter: `genre = "horror`, wantOffset: 8},
+		{name: "TestParseUnbalancedParentheses", filter: `(a = 1 OR b = 2`, wantOffset: 15},
+		{name: "TestParseTrailingToken", filter: `a = 1 b`, wantOffset: 6},
+		{name: "TestParseBadOperator", filter: `a ! 1`, wantOffset: 2},
+		{name: "TestParseKeywordAsValue", filter: `a = AND`, wantOffset: 4},
+		{name: "TestParseGeoRadiusArguments", filter: `_geoRadius(1, 2)`, wantOffset: 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.filter)
+			require.Error(t, err)
+			syntaxErr, ok := err.(*SyntaxError)
+			require.True(t, ok)
+			require.Equal(t, tt.wantOffset, syntaxErr.Offset)
+		})
+	}
+}
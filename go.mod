@@ -3,6 +3,7 @@ module github.com/meilisearch/meilisearch-go
 go 1.16
 
 require (
+	github.com/klauspost/compress v1.13.4
 	github.com/mailru/easyjson v0.7.7
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.7.0
@@ -2,6 +2,9 @@ package meilisearch
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -34,12 +37,25 @@ type IndexInterface interface {
 	AddDocumentsNdjson(documents []byte, primaryKey ...string) (resp *AsyncUpdateID, err error)
 	AddDocumentsNdjsonInBatches(documents []byte, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error)
 	UpdateDocuments(documentsPtr interface{}, primaryKey ...string) (resp *AsyncUpdateID, err error)
+	UpdateDocumentsCsv(documents []byte, primaryKey ...string) (resp *AsyncUpdateID, err error)
+	UpdateDocumentsCsvInBatches(documents []byte, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error)
+	UpdateDocumentsNdjson(documents []byte, primaryKey ...string) (resp *AsyncUpdateID, err error)
+	UpdateDocumentsNdjsonInBatches(documents []byte, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error)
 	GetDocument(uid string, documentPtr interface{}) error
 	GetDocuments(request *DocumentsRequest, resp interface{}) error
+	ExportDocuments(ctx context.Context, w io.Writer, format ExportFormat) error
 	DeleteDocument(uid string) (resp *AsyncUpdateID, err error)
 	DeleteDocuments(uid []string) (resp *AsyncUpdateID, err error)
+	DeleteDocumentsByFilter(ctx context.Context, filter interface{}) (resp []AsyncUpdateID, err error)
 	DeleteAllDocuments() (resp *AsyncUpdateID, err error)
 	Search(query string, request *SearchRequest) (*SearchResponse, error)
+	SearchInto(query string, request *SearchRequest, hitsPtr interface{}) (*SearchResponse, error)
+	SearchRaw(query string, request *SearchRequest) (*SearchResponse, []json.RawMessage, error)
+	SearchWith(search SearchBuilder) (*SearchResponse, error)
+	SearchWithFacetSelection(query string, request *SearchRequest, selection FacetSelection) (*SearchResponse, error)
+	CheckGeoSettings(filterable, sortable bool) error
+	SearchAll(ctx context.Context, query string, request *SearchRequest, opts *SearchAllOptions) *SearchIterator
+	ScanDocuments(ctx context.Context, filter interface{}, opts *ScanOptions) *SearchIterator
 
 	GetUpdateStatus(updateID int64) (resp *Update, err error)
 	GetAllUpdateStatus() (resp *[]Update, err error)
@@ -192,6 +208,7 @@ func (i Index) GetUpdateStatus(updateID int64) (resp *Update, err error) {
 	if err := i.client.executeRequest(req); err != nil {
 		return nil, err
 	}
+	i.client.searchCacheUpdateSeen(i.UID, resp)
 	return resp, nil
 }
 
@@ -208,6 +225,9 @@ func (i Index) GetAllUpdateStatus() (resp *[]Update, err error) {
 	if err := i.client.executeRequest(req); err != nil {
 		return nil, err
 	}
+	for j := range *resp {
+		i.client.searchCacheUpdateSeen(i.UID, &(*resp)[j])
+	}
 	return resp, nil
 }
 
@@ -241,3 +261,24 @@ func (i Index) WaitForPendingUpdate(
 		time.Sleep(interval)
 	}
 }
+
+// waitForUpdates waits for the end of every update and returns an error if one
+// of them has not been processed successfully.
+func (i Index) waitForUpdates(ctx context.Context, interval time.Duration, updateIDs []AsyncUpdateID) error {
+	for _, updateID := range updateIDs {
+		updateID := updateID
+		status, err := i.WaitForPendingUpdate(ctx, interval, &updateID)
+		if err != nil {
+			return err
+		}
+		if status == UpdateStatusProcessed {
+			continue
+		}
+		update, err := i.GetUpdateStatus(updateID.UpdateID)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("update %d of index %q is %s: %s", update.UpdateID, i.UID, update.Status, update.Error)
+	}
+	return nil
+}
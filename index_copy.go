@@ -0,0 +1,144 @@
+package meilisearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// CopyIndexOptions configure CopyIndex
+type CopyIndexOptions struct {
+
+	// BatchSize is the number of documents read from the source and sent to
+	// the destination at a time. Default to 1000.
+	BatchSize int64
+
+	// PrimaryKey is the primary key of the destination index.
+	// Default to the primary key of the source index.
+	PrimaryKey string
+
+	// SkipSettings disables the copy of the settings
+	SkipSettings bool
+
+	// Transform is optional, it is called for every document before it is
+	// sent to the destination. Returning a nil document drops it.
+	// Numbers are decoded as json.Number, when the JSON codec of the client
+	// supports it, so they are copied exactly.
+	Transform func(document map[string]interface{}) (map[string]interface{}, error)
+
+	// WaitInterval is the interval between two update status checks.
+	// Default to 50ms.
+	WaitInterval time.Duration
+}
+
+// CopyIndexResult is returned by CopyIndex
+type CopyIndexResult struct {
+	// Settings is the update of the destination settings, nil if skipped
+	Settings *AsyncUpdateID
+	// Updates are the document additions sent to the destination
+	Updates []AsyncUpdateID
+	// NumberOfDocuments is the number of documents sent to the destination
+	NumberOfDocuments int64
+}
+
+// CopyIndex copies the settings then the documents of src into dst.
+// Both indexes may belong to different clients, so it can be used to migrate
+// an index to another server or to reindex with a different primary key.
+//
+// Documents are streamed by batches, CopyIndex waits for every update to be
+// processed and checks that the destination holds as many documents as were
+// sent, dst is therefore expected to be empty.
+func CopyIndex(ctx context.Context, src *Index, dst *Index, opts *CopyIndexOptions) (resp *CopyIndexResult, err error) {
+	if opts == nil {
+		opts = &CopyIndexOptions{}
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	interval := opts.WaitInterval
+	if interval <= 0 {
+		interval = time.Millisecond * 50
+	}
+	resp = &CopyIndexResult{}
+
+	primaryKey := opts.PrimaryKey
+	if primaryKey == "" {
+		srcPrimaryKey, err := src.FetchPrimaryKey()
+		if err != nil {
+			return nil, err
+		}
+		primaryKey = *srcPrimaryKey
+	}
+	var primaryKeys []string
+	if primaryKey != "" {
+		primaryKeys = []string{primaryKey}
+	}
+
+	if !opts.SkipSettings {
+		settings, err := src.GetSettings()
+		if err != nil {
+			return nil, err
+		}
+		resp.Settings, err = dst.UpdateSettings(settings)
+		if err != nil {
+			return nil, err
+		}
+		// Settings are applied first so documents are only indexed once
+		if err := dst.waitForUpdates(ctx, interval, []AsyncUpdateID{*resp.Settings}); err != nil {
+			return resp, err
+		}
+	}
+
+	err = src.forEachDocumentsPage(ctx, batchSize, nil, func(page []json.RawMessage) error {
+		var batch interface{} = page
+		if opts.Transform != nil {
+			documents := make([]map[string]interface{}, 0, len(page))
+			for _, raw := range page {
+				document, err := decodeDocument(src.client.jsonCodec(), raw)
+				if err != nil {
+					return err
+				}
+				transformed, err := opts.Transform(document)
+				if err != nil {
+					return err
+				}
+				if transformed != nil {
+					documents = append(documents, transformed)
+				}
+			}
+			if len(documents) == 0 {
+				return nil
+			}
+			batch = documents
+			resp.NumberOfDocuments += int64(len(documents))
+		} else {
+			resp.NumberOfDocuments += int64(len(page))
+		}
+
+		update, err := dst.AddDocuments(batch, primaryKeys...)
+		if err != nil {
+			return err
+		}
+		resp.Updates = append(resp.Updates, *update)
+		return nil
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	if err := dst.waitForUpdates(ctx, interval, resp.Updates); err != nil {
+		return resp, err
+	}
+
+	stats, err := dst.GetStats()
+	if err != nil {
+		return resp, err
+	}
+	if stats.NumberOfDocuments != resp.NumberOfDocuments {
+		return resp, fmt.Errorf("index %q holds %d documents after the copy, %d were sent",
+			dst.UID, stats.NumberOfDocuments, resp.NumberOfDocuments)
+	}
+	return resp, nil
+}
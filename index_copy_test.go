@@ -0,0 +1,135 @@
+package meilisearch
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyIndex(t *testing.T) {
+	type args struct {
+		srcClient *Client
+		dstClient *Client
+		dstUID    string
+		opts      *CopyIndexOptions
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantDocuments int64
+		wantSettings  bool
+	}{
+		{
+			name: "TestCopyIndexBasic",
+			args: args{
+				srcClient: defaultClient,
+				dstClient: defaultClient,
+				dstUID:    "TestCopyIndexBasic",
+				opts:      nil,
+			},
+			wantDocuments: 6,
+			wantSettings:  true,
+		},
+		{
+			name: "TestCopyIndexToCustomClientInBatches",
+			args: args{
+				srcClient: defaultClient,
+				dstClient: customClient,
+				dstUID:    "TestCopyIndexToCustomClientInBatches",
+				opts: &CopyIndexOptions{
+					BatchSize: 4,
+				},
+			},
+			wantDocuments: 6,
+			wantSettings:  true,
+		},
+		{
+			name: "TestCopyIndexWithTransform",
+			args: args{
+				srcClient: defaultClient,
+				dstClient: defaultClient,
+				dstUID:    "TestCopyIndexWithTransform",
+				opts: &CopyIndexOptions{
+					BatchSize:    2,
+					PrimaryKey:   "id",
+					SkipSettings: true,
+					Transform: func(document map[string]interface{}) (map[string]interface{}, error) {
+						if document["book_id"] == json.Number("1344") {
+							return nil, nil
+						}
+						document["id"] = document["book_id"]
+						delete(document, "book_id")
+						return document, nil
+					},
+				},
+			},
+			wantDocuments: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := tt.args.srcClient.Index("indexUID")
+			dst := tt.args.dstClient.Index(tt.args.dstUID)
+			t.Cleanup(cleanup(tt.args.srcClient))
+			SetUpBasicIndex()
+
+			_, err := src.UpdateSettings(&Settings{
+				FilterableAttributes: []string{"title"},
+			})
+			require.NoError(t, err)
+
+			gotResp, err := CopyIndex(context.Background(), src, dst, tt.args.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDocuments, gotResp.NumberOfDocuments)
+			require.Equal(t, tt.wantSettings, gotResp.Settings != nil)
+
+			stats, err := dst.GetStats()
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDocuments, stats.NumberOfDocuments)
+
+			filterableAttributes, err := dst.GetFilterableAttributes()
+			require.NoError(t, err)
+			if tt.wantSettings {
+				require.Equal(t, &[]string{"title"}, filterableAttributes)
+			} else {
+				require.Empty(t, filterableAttributes)
+			}
+		})
+	}
+}
+
+func TestCopyIndexLargeIDs(t *testing.T) {
+	c := defaultClient
+	src := c.Index("copylargeids")
+	dst := c.Index("copylargeidsdst")
+	t.Cleanup(cleanup(c))
+
+	// Above 2^53, changed by a float64 roundtrip
+	update, err := src.AddDocuments([]map[string]interface{}{
+		{"id": int64(9007199254740993), "count": int64(9007199254740995)},
+	}, "id")
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, src, update)
+
+	gotResp, err := CopyIndex(context.Background(), src, dst, &CopyIndexOptions{
+		SkipSettings: true,
+		Transform: func(document map[string]interface{}) (map[string]interface{}, error) {
+			return document, nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), gotResp.NumberOfDocuments)
+
+	testWaitForPendingBatchUpdate(t, dst, gotResp.Updates)
+
+	var raw json.RawMessage
+	require.NoError(t, dst.GetDocument("9007199254740993", &raw))
+	document, err := decodeDocument(c.jsonCodec(), raw)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"id":    json.Number("9007199254740993"),
+		"count": json.Number("9007199254740995"),
+	}, document)
+}
@@ -3,7 +3,10 @@ package meilisearch
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/csv"
+	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"math"
@@ -11,6 +14,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -55,7 +59,36 @@ func (i Index) GetDocuments(request *DocumentsRequest, resp interface{}) error {
 	return nil
 }
 
-func (i Index) addDocuments(documentsPtr interface{}, contentType string, primaryKey ...string) (resp *AsyncUpdateID, err error) {
+// addDocuments sends documents with POST to add or replace them, or with PUT
+// to add or update them.
+func (i Index) addDocuments(documentsPtr interface{}, contentType string, method string, primaryKey ...string) (resp *AsyncUpdateID, err error) {
+	return i.documentsAdder(primaryKey...)(documentsPtr, contentType, method)
+}
+
+// documentsAdder returns a function sending documents as addDocuments does,
+// to be called for every batch of an import. The primary key documents are
+// validated against is resolved by the first call only, so the index is not
+// fetched once per batch.
+func (i Index) documentsAdder(primaryKey ...string) func(documents interface{}, contentType string, method string) (*AsyncUpdateID, error) {
+	var validationKey *string
+	return func(documents interface{}, contentType string, method string) (*AsyncUpdateID, error) {
+		if i.client.config.ValidateDocuments {
+			if validationKey == nil {
+				key, err := i.validationPrimaryKey(primaryKey...)
+				if err != nil {
+					return nil, err
+				}
+				validationKey = &key
+			}
+			if err := i.validateDocuments(documents, contentType, *validationKey); err != nil {
+				return nil, err
+			}
+		}
+		return i.sendDocuments(documents, contentType, method, primaryKey...)
+	}
+}
+
+func (i Index) sendDocuments(documentsPtr interface{}, contentType string, method string, primaryKey ...string) (resp *AsyncUpdateID, err error) {
 	resp = &AsyncUpdateID{}
 	endpoint := ""
 	if primaryKey == nil {
@@ -66,13 +99,16 @@ func (i Index) addDocuments(documentsPtr interface{}, contentType string, primar
 	}
 	req := internalRequest{
 		endpoint:            endpoint,
-		method:              http.MethodPost,
+		method:              method,
 		contentType:         contentType,
 		withRequest:         documentsPtr,
 		withResponse:        resp,
 		acceptedStatusCodes: []int{http.StatusAccepted},
 		functionName:        "AddDocuments",
 	}
+	if method == http.MethodPut {
+		req.functionName = "UpdateDocuments"
+	}
 	if err = i.client.executeRequest(req); err != nil {
 		return nil, err
 	}
@@ -80,14 +116,19 @@ func (i Index) addDocuments(documentsPtr interface{}, contentType string, primar
 }
 
 func (i Index) AddDocuments(documentsPtr interface{}, primaryKey ...string) (resp *AsyncUpdateID, err error) {
-	return i.addDocuments(documentsPtr, contentTypeJSON, primaryKey...)
+	return i.addDocuments(documentsPtr, contentTypeJSON, http.MethodPost, primaryKey...)
 }
 
 func (i Index) AddDocumentsInBatches(documentsPtr interface{}, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	return i.addDocumentsInBatches(documentsPtr, batchSize, http.MethodPost, primaryKey...)
+}
+
+func (i Index) addDocumentsInBatches(documentsPtr interface{}, batchSize int, method string, primaryKey ...string) (resp []AsyncUpdateID, err error) {
 	arr := reflect.ValueOf(documentsPtr)
 	lenDocs := arr.Len()
 	numBatches := int(math.Ceil(float64(lenDocs) / float64(batchSize)))
 	resp = make([]AsyncUpdateID, numBatches)
+	addDocuments := i.documentsAdder(primaryKey...)
 
 	for j := 0; j < numBatches; j++ {
 		end := (j + 1) * batchSize
@@ -97,21 +138,12 @@ func (i Index) AddDocumentsInBatches(documentsPtr interface{}, batchSize int, pr
 
 		batch := arr.Slice(j*batchSize, end).Interface()
 
-		if primaryKey != nil {
-			respID, err := i.AddDocuments(batch, primaryKey[0])
-			if err != nil {
-				return nil, err
-			}
-
-			resp[j] = *respID
-		} else {
-			respID, err := i.AddDocuments(batch)
-			if err != nil {
-				return nil, err
-			}
-
-			resp[j] = *respID
+		respID, err := addDocuments(batch, contentTypeJSON, method)
+		if err != nil {
+			return nil, err
 		}
+
+		resp[j] = *respID
 	}
 
 	return resp, nil
@@ -119,7 +151,7 @@ func (i Index) AddDocumentsInBatches(documentsPtr interface{}, batchSize int, pr
 
 func (i Index) AddDocumentsCsv(documents []byte, primaryKey ...string) (resp *AsyncUpdateID, err error) {
 	// []byte avoids JSON conversion in Client.sendRequest()
-	return i.addDocuments(documents, contentTypeCSV, primaryKey...)
+	return i.addDocuments(documents, contentTypeCSV, http.MethodPost, primaryKey...)
 }
 
 func (i Index) AddDocumentsCsvFromReader(documents io.Reader, primaryKey ...string) (resp *AsyncUpdateID, err error) {
@@ -129,7 +161,7 @@ func (i Index) AddDocumentsCsvFromReader(documents io.Reader, primaryKey ...stri
 	if err != nil {
 		return nil, errors.Wrap(err, "could not read documents")
 	}
-	return i.addDocuments(data, contentTypeCSV, primaryKey...)
+	return i.addDocuments(data, contentTypeCSV, http.MethodPost, primaryKey...)
 }
 
 func (i Index) AddDocumentsCsvInBatches(documents []byte, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
@@ -138,6 +170,10 @@ func (i Index) AddDocumentsCsvInBatches(documents []byte, batchSize int, primary
 }
 
 func (i Index) AddDocumentsCsvFromReaderInBatches(documents io.Reader, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	return i.addDocumentsCsvFromReaderInBatches(documents, batchSize, http.MethodPost, primaryKey...)
+}
+
+func (i Index) addDocumentsCsvFromReaderInBatches(documents io.Reader, batchSize int, method string, primaryKey ...string) (resp []AsyncUpdateID, err error) {
 	// Because of the possibility of multiline fields it's not safe to split
 	// into batches by lines, we'll have to parse the file and reassemble it
 	// into smaller parts. RFC 4180 compliant input with a header row is
@@ -146,28 +182,57 @@ func (i Index) AddDocumentsCsvFromReaderInBatches(documents io.Reader, batchSize
 	// into memory. However, this means that only part of the documents might
 	// be added successfully.
 
-	var (
-		responses []AsyncUpdateID
-		header    []string
-		records   [][]string
-	)
+	var responses []AsyncUpdateID
+	addDocuments := i.documentsAdder(primaryKey...)
 
 	sendCsvRecords := func(records [][]string) (*AsyncUpdateID, error) {
-		b := new(bytes.Buffer)
-		w := csv.NewWriter(b)
-		w.UseCRLF = true // Keep output RFC 4180 compliant
-		err := w.WriteAll(records)
+		data, err := csvBody(records)
 		if err != nil {
-			return nil, errors.Wrap(err, "could not write CSV records")
+			return nil, err
 		}
 
-		resp, err := i.AddDocumentsCsv(b.Bytes(), primaryKey...)
+		resp, err := addDocuments(data, contentTypeCSV, method)
 		if err != nil {
 			return nil, err
 		}
 		return resp, nil
 	}
 
+	err = readCsvBatches(documents, batchSize, 0, func(records [][]string) error {
+		resp, err := sendCsvRecords(records)
+		if err != nil {
+			return err
+		}
+		responses = append(responses, *resp)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// csvBody assembles records into an RFC 4180 CSV file
+func csvBody(records [][]string) ([]byte, error) {
+	b := new(bytes.Buffer)
+	w := csv.NewWriter(b)
+	w.UseCRLF = true // Keep output RFC 4180 compliant
+	if err := w.WriteAll(records); err != nil {
+		return nil, errors.Wrap(err, "could not write CSV records")
+	}
+	return b.Bytes(), nil
+}
+
+// readCsvBatches reads CSV records and calls send with every batch of
+// batchSize records, preceded by the header record. The first skip records
+// after the header are ignored.
+func readCsvBatches(documents io.Reader, batchSize int, skip int64, send func(records [][]string) error) error {
+	var (
+		header  []string
+		records [][]string
+	)
+
 	r := csv.NewReader(documents)
 	for {
 		// Read CSV record (empty lines and comments are already skipped by csv.Reader)
@@ -176,7 +241,7 @@ func (i Index) AddDocumentsCsvFromReaderInBatches(documents io.Reader, batchSize
 			break
 		}
 		if err != nil {
-			return nil, errors.Wrap(err, "could not read CSV record")
+			return errors.Wrap(err, "could not read CSV record")
 		}
 
 		// Store first record as header
@@ -185,6 +250,11 @@ func (i Index) AddDocumentsCsvFromReaderInBatches(documents io.Reader, batchSize
 			continue
 		}
 
+		if skip > 0 {
+			skip--
+			continue
+		}
+
 		// Add header record to every batch
 		if len(records) == 0 {
 			records = append(records, header)
@@ -192,32 +262,25 @@ func (i Index) AddDocumentsCsvFromReaderInBatches(documents io.Reader, batchSize
 
 		records = append(records, record)
 
-		// After reaching batchSize (not counting the header record) assemble a CSV file and send records
+		// After reaching batchSize (not counting the header record) send records
 		if len(records) == batchSize+1 {
-			resp, err := sendCsvRecords(records)
-			if err != nil {
-				return nil, err
+			if err := send(records); err != nil {
+				return err
 			}
-			responses = append(responses, *resp)
 			records = nil
 		}
 	}
 
 	// Send remaining records as the last batch if there is any
 	if len(records) > 0 {
-		resp, err := sendCsvRecords(records)
-		if err != nil {
-			return nil, err
-		}
-		responses = append(responses, *resp)
+		return send(records)
 	}
-
-	return responses, nil
+	return nil
 }
 
 func (i Index) AddDocumentsNdjson(documents []byte, primaryKey ...string) (resp *AsyncUpdateID, err error) {
 	// []byte avoids JSON conversion in Client.sendRequest()
-	return i.addDocuments([]byte(documents), contentTypeNDJSON, primaryKey...)
+	return i.addDocuments(documents, contentTypeNDJSON, http.MethodPost, primaryKey...)
 }
 
 func (i Index) AddDocumentsNdjsonFromReader(documents io.Reader, primaryKey ...string) (resp *AsyncUpdateID, err error) {
@@ -227,7 +290,7 @@ func (i Index) AddDocumentsNdjsonFromReader(documents io.Reader, primaryKey ...s
 	if err != nil {
 		return nil, errors.Wrap(err, "could not read documents")
 	}
-	return i.addDocuments(data, contentTypeNDJSON, primaryKey...)
+	return i.addDocuments(data, contentTypeNDJSON, http.MethodPost, primaryKey...)
 }
 
 func (i Index) AddDocumentsNdjsonInBatches(documents []byte, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
@@ -236,38 +299,69 @@ func (i Index) AddDocumentsNdjsonInBatches(documents []byte, batchSize int, prim
 }
 
 func (i Index) AddDocumentsNdjsonFromReaderInBatches(documents io.Reader, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	return i.addDocumentsNdjsonFromReaderInBatches(documents, batchSize, http.MethodPost, primaryKey...)
+}
+
+func (i Index) addDocumentsNdjsonFromReaderInBatches(documents io.Reader, batchSize int, method string, primaryKey ...string) (resp []AsyncUpdateID, err error) {
 	// NDJSON files supposed to contain a valid JSON document in each line, so
 	// it's safe to split by lines.
 	// Lines are read and sent continuously to avoid reading all content into
 	// memory. However, this means that only part of the documents might be
 	// added successfully.
 
-	sendNdjsonLines := func(lines []string) (*AsyncUpdateID, error) {
-		b := new(bytes.Buffer)
-		for _, line := range lines {
-			_, err := b.WriteString(line)
-			if err != nil {
-				return nil, errors.Wrap(err, "could not write NDJSON line")
-			}
-			err = b.WriteByte('\n')
-			if err != nil {
-				return nil, errors.Wrap(err, "could not write NDJSON line")
-			}
-		}
+	addDocuments := i.documentsAdder(primaryKey...)
 
-		resp, err := i.AddDocumentsNdjson(b.Bytes(), primaryKey...)
+	sendNdjsonLines := func(lines []string) (*AsyncUpdateID, error) {
+		resp, err := addDocuments(ndjsonBody(lines), contentTypeNDJSON, method)
 		if err != nil {
 			return nil, err
 		}
 		return resp, nil
 	}
 
+	var responses []AsyncUpdateID
+
+	err = readNdjsonBatches(documents, batchSize, 0, func(lines []string, _ int64) error {
+		resp, err := sendNdjsonLines(lines)
+		if err != nil {
+			return err
+		}
+		responses = append(responses, *resp)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// ndjsonBody assembles lines into an NDJSON file
+func ndjsonBody(lines []string) []byte {
+	b := new(bytes.Buffer)
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
+
+// readNdjsonBatches reads NDJSON lines and calls send with every batch of
+// batchSize lines and the number of bytes read from documents so far. The
+// first skip lines are ignored.
+func readNdjsonBatches(documents io.Reader, batchSize int, skip int64, send func(lines []string, offset int64) error) error {
 	var (
-		responses []AsyncUpdateID
-		lines     []string
+		lines  []string
+		offset int64
 	)
 
 	scanner := bufio.NewScanner(documents)
+	scanner.Split(func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		// Count consumed bytes so the offset of every batch is known
+		advance, token, err = bufio.ScanLines(data, atEOF)
+		offset += int64(advance)
+		return advance, token, err
+	})
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 
@@ -276,24 +370,104 @@ func (i Index) AddDocumentsNdjsonFromReaderInBatches(documents io.Reader, batchS
 			continue
 		}
 
+		if skip > 0 {
+			skip--
+			continue
+		}
+
 		lines = append(lines, line)
 		// After reaching batchSize send NDJSON lines
 		if len(lines) == batchSize {
-			resp, err := sendNdjsonLines(lines)
-			if err != nil {
-				return nil, err
+			if err := send(lines, offset); err != nil {
+				return err
 			}
-			responses = append(responses, *resp)
 			lines = nil
 		}
 	}
 	if err := scanner.Err(); err != nil {
-		return nil, errors.Wrap(err, "could not read NDJSON")
+		return errors.Wrap(err, "could not read NDJSON")
 	}
 
-	// Send remaining records as the last batch if there is any
+	// Send remaining lines as the last batch if there is any
 	if len(lines) > 0 {
-		resp, err := sendNdjsonLines(lines)
+		return send(lines, offset)
+	}
+	return nil
+}
+
+func (i Index) AddDocumentsJSONFromReaderInBatches(documents io.Reader, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	return i.addDocumentsJSONFromReaderInBatches(documents, batchSize, http.MethodPost, primaryKey...)
+}
+
+func (i Index) addDocumentsJSONFromReaderInBatches(documents io.Reader, batchSize int, method string, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	// The JSON array is tokenised with a streaming decoder and every document
+	// is kept as raw JSON, so it is never re-marshalled and only one batch is
+	// held in memory.
+	// Documents are sent continuously, as with NDJSON this means that only
+	// part of the documents might be added successfully.
+
+	addDocuments := i.documentsAdder(primaryKey...)
+
+	sendJSONDocuments := func(documents []json.RawMessage) (*AsyncUpdateID, error) {
+		b := new(bytes.Buffer)
+		b.WriteByte('[')
+		for j, document := range documents {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			b.Write(document)
+		}
+		b.WriteByte(']')
+
+		resp, err := addDocuments(b.Bytes(), contentTypeJSON, method)
+		if err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	var (
+		responses []AsyncUpdateID
+		batch     []json.RawMessage
+	)
+
+	// The codec decoder is used when it can read the array brackets
+	d, ok := i.client.jsonCodec().NewDecoder(documents).(jsonTokenDecoder)
+	if !ok {
+		d = json.NewDecoder(documents)
+	}
+	token, err := d.Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read JSON")
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("could not read JSON: documents must be an array")
+	}
+	for d.More() {
+		var document json.RawMessage
+		if err := d.Decode(&document); err != nil {
+			return nil, errors.Wrap(err, "could not read JSON document")
+		}
+
+		batch = append(batch, document)
+		// After reaching batchSize send JSON documents
+		if len(batch) == batchSize {
+			resp, err := sendJSONDocuments(batch)
+			if err != nil {
+				return nil, err
+			}
+			responses = append(responses, *resp)
+			batch = nil
+		}
+	}
+	// Consume the closing bracket so truncated input is reported
+	if _, err := d.Token(); err != nil {
+		return nil, errors.Wrap(err, "could not read JSON")
+	}
+
+	// Send remaining documents as the last batch if there is any
+	if len(batch) > 0 {
+		resp, err := sendJSONDocuments(batch)
 		if err != nil {
 			return nil, err
 		}
@@ -304,60 +478,61 @@ func (i Index) AddDocumentsNdjsonFromReaderInBatches(documents io.Reader, batchS
 }
 
 func (i Index) UpdateDocuments(documentsPtr interface{}, primaryKey ...string) (resp *AsyncUpdateID, err error) {
-	resp = &AsyncUpdateID{}
-	endpoint := ""
-	if primaryKey == nil {
-		endpoint = "/indexes/" + i.UID + "/documents"
-	} else {
-		i.PrimaryKey = primaryKey[0] //nolint:golint,staticcheck
-		endpoint = "/indexes/" + i.UID + "/documents?primaryKey=" + primaryKey[0]
-	}
-	req := internalRequest{
-		endpoint:            endpoint,
-		method:              http.MethodPut,
-		contentType:         contentTypeJSON,
-		withRequest:         documentsPtr,
-		withResponse:        resp,
-		acceptedStatusCodes: []int{http.StatusAccepted},
-		functionName:        "UpdateDocuments",
-	}
-	if err = i.client.executeRequest(req); err != nil {
-		return nil, err
-	}
-	return resp, nil
+	return i.addDocuments(documentsPtr, contentTypeJSON, http.MethodPut, primaryKey...)
 }
 
 func (i Index) UpdateDocumentsInBatches(documentsPtr interface{}, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
-	arr := reflect.ValueOf(documentsPtr)
-	lenDocs := arr.Len()
-	numBatches := int(math.Ceil(float64(lenDocs) / float64(batchSize)))
-	resp = make([]AsyncUpdateID, numBatches)
+	return i.addDocumentsInBatches(documentsPtr, batchSize, http.MethodPut, primaryKey...)
+}
 
-	for j := 0; j < numBatches; j++ {
-		end := (j + 1) * batchSize
-		if end > lenDocs {
-			end = lenDocs
-		}
+func (i Index) UpdateDocumentsJSONFromReaderInBatches(documents io.Reader, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	return i.addDocumentsJSONFromReaderInBatches(documents, batchSize, http.MethodPut, primaryKey...)
+}
 
-		batch := arr.Slice(j*batchSize, end).Interface()
-		if primaryKey != nil {
-			respID, err := i.UpdateDocuments(batch, primaryKey[0])
-			if err != nil {
-				return nil, err
-			}
+func (i Index) UpdateDocumentsCsv(documents []byte, primaryKey ...string) (resp *AsyncUpdateID, err error) {
+	// []byte avoids JSON conversion in Client.sendRequest()
+	return i.addDocuments(documents, contentTypeCSV, http.MethodPut, primaryKey...)
+}
 
-			resp[j] = *respID
-		} else {
-			respID, err := i.UpdateDocuments(batch)
-			if err != nil {
-				return nil, err
-			}
+func (i Index) UpdateDocumentsCsvFromReader(documents io.Reader, primaryKey ...string) (resp *AsyncUpdateID, err error) {
+	// Read content to memory because of problems with streamed bodies
+	data, err := ioutil.ReadAll(documents)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read documents")
+	}
+	return i.addDocuments(data, contentTypeCSV, http.MethodPut, primaryKey...)
+}
 
-			resp[j] = *respID
-		}
+func (i Index) UpdateDocumentsCsvInBatches(documents []byte, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	// Reuse io.Reader implementation
+	return i.UpdateDocumentsCsvFromReaderInBatches(bytes.NewReader(documents), batchSize, primaryKey...)
+}
+
+func (i Index) UpdateDocumentsCsvFromReaderInBatches(documents io.Reader, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	return i.addDocumentsCsvFromReaderInBatches(documents, batchSize, http.MethodPut, primaryKey...)
+}
+
+func (i Index) UpdateDocumentsNdjson(documents []byte, primaryKey ...string) (resp *AsyncUpdateID, err error) {
+	// []byte avoids JSON conversion in Client.sendRequest()
+	return i.addDocuments(documents, contentTypeNDJSON, http.MethodPut, primaryKey...)
+}
+
+func (i Index) UpdateDocumentsNdjsonFromReader(documents io.Reader, primaryKey ...string) (resp *AsyncUpdateID, err error) {
+	// Read content to memory because of problems with streamed bodies
+	data, err := ioutil.ReadAll(documents)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read documents")
 	}
+	return i.addDocuments(data, contentTypeNDJSON, http.MethodPut, primaryKey...)
+}
 
-	return resp, nil
+func (i Index) UpdateDocumentsNdjsonInBatches(documents []byte, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	// Reuse io.Reader implementation
+	return i.UpdateDocumentsNdjsonFromReaderInBatches(bytes.NewReader(documents), batchSize, primaryKey...)
+}
+
+func (i Index) UpdateDocumentsNdjsonFromReaderInBatches(documents io.Reader, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	return i.addDocumentsNdjsonFromReaderInBatches(documents, batchSize, http.MethodPut, primaryKey...)
 }
 
 func (i Index) DeleteDocument(identifier string) (resp *AsyncUpdateID, err error) {
@@ -393,6 +568,96 @@ func (i Index) DeleteDocuments(identifier []string) (resp *AsyncUpdateID, err er
 	return resp, nil
 }
 
+// deleteByFilterPageSize is the number of documents deleted at a time when the
+// server has no delete by filter endpoint.
+const deleteByFilterPageSize int64 = 1000
+
+// DeleteDocumentsByFilter deletes every document matching the filter, the
+// filter uses the same syntax as SearchRequest.Filter.
+//
+// The delete by filter endpoint of the server is used when it exists.
+// Otherwise the matching documents are searched page by page, retrieving only
+// their primary key, and deleted with DeleteDocuments; every batch is waited
+// for before the next search. The filtered attributes must be filterable.
+func (i Index) DeleteDocumentsByFilter(ctx context.Context, filter interface{}) (resp []AsyncUpdateID, err error) {
+	update := &AsyncUpdateID{}
+	req := internalRequest{
+		endpoint:            "/indexes/" + i.UID + "/documents/delete",
+		method:              http.MethodPost,
+		contentType:         contentTypeJSON,
+		withRequest:         map[string]interface{}{"filter": filter},
+		withResponse:        update,
+		acceptedStatusCodes: []int{http.StatusAccepted},
+		functionName:        "DeleteDocumentsByFilter",
+	}
+	err = i.client.executeRequest(req)
+	if err == nil {
+		return []AsyncUpdateID{*update}, nil
+	}
+	if !isEndpointNotFound(err) {
+		return nil, err
+	}
+
+	primaryKey, err := i.FetchPrimaryKey()
+	if err != nil {
+		return nil, err
+	}
+	if *primaryKey == "" {
+		// An index without primary key has no documents
+		return nil, nil
+	}
+
+	deleted := map[string]bool{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return resp, err
+		}
+		// Hits are kept raw and decoded with json.Number, integer primary
+		// keys above 2^53 would be rounded as float64
+		_, hits, err := i.SearchRaw("", &SearchRequest{
+			PlaceholderSearch:    true,
+			Filter:               filter,
+			AttributesToRetrieve: []string{*primaryKey},
+			Limit:                deleteByFilterPageSize,
+		})
+		if err != nil {
+			return resp, err
+		}
+		if len(hits) == 0 {
+			return resp, nil
+		}
+
+		identifiers := make([]string, 0, len(hits))
+		for _, hit := range hits {
+			document, err := decodeDocument(i.client.jsonCodec(), hit)
+			if err != nil {
+				return resp, err
+			}
+			identifier, ok := documentID(document, *primaryKey)
+			if !ok {
+				return resp, fmt.Errorf("unexpected primary key %q in search hit: %s", *primaryKey, hit)
+			}
+			// A document still matching after its deletion was processed
+			// would make this loop endless
+			if deleted[identifier] {
+				return resp, fmt.Errorf("document %q still matches the filter after its deletion", identifier)
+			}
+			deleted[identifier] = true
+			identifiers = append(identifiers, identifier)
+		}
+
+		update, err := i.DeleteDocuments(identifiers)
+		if err != nil {
+			return resp, err
+		}
+		resp = append(resp, *update)
+		// Deleted documents must be gone before searching the next page
+		if err := i.waitForUpdates(ctx, time.Millisecond*50, []AsyncUpdateID{*update}); err != nil {
+			return resp, err
+		}
+	}
+}
+
 func (i Index) DeleteAllDocuments() (resp *AsyncUpdateID, err error) {
 	resp = &AsyncUpdateID{}
 	req := internalRequest{
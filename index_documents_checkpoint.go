@@ -0,0 +1,200 @@
+package meilisearch
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Checkpoint records the progress of a batched import
+type Checkpoint struct {
+	// Records is the number of documents already sent
+	Records int64 `json:"records"`
+	// Offset is the byte offset of the input after the last sent batch, only
+	// known for NDJSON
+	Offset int64 `json:"offset"`
+	// UpdateIDs are the updates of the batches already sent
+	UpdateIDs []AsyncUpdateID `json:"updateIds"`
+}
+
+// CheckpointStore persists the checkpoints of batched imports by key
+type CheckpointStore interface {
+	// Load returns the checkpoint of key, nil if there is none
+	Load(key string) (*Checkpoint, error)
+	Save(key string, checkpoint *Checkpoint) error
+	Delete(key string) error
+}
+
+// FileCheckpointStore stores every checkpoint as a JSON file in Dir
+type FileCheckpointStore struct {
+	Dir string
+
+	// JSONCodec encodes the files. Default to DefaultJSONCodec.
+	JSONCodec JSONCodec
+}
+
+func (s FileCheckpointStore) path(key string) string {
+	return filepath.Join(s.Dir, strings.ReplaceAll(key, string(filepath.Separator), "_")+".checkpoint.json")
+}
+
+// Load reads the checkpoint file of key
+func (s FileCheckpointStore) Load(key string) (*Checkpoint, error) {
+	data, err := ioutil.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read checkpoint")
+	}
+	checkpoint := &Checkpoint{}
+	if err := codecOrDefault(s.JSONCodec).Unmarshal(data, checkpoint); err != nil {
+		return nil, errors.Wrap(err, "could not decode checkpoint")
+	}
+	return checkpoint, nil
+}
+
+// Save writes the checkpoint file of key
+func (s FileCheckpointStore) Save(key string, checkpoint *Checkpoint) error {
+	data, err := codecOrDefault(s.JSONCodec).Marshal(checkpoint)
+	if err != nil {
+		return errors.Wrap(err, "could not encode checkpoint")
+	}
+	// Write to a temporary file first so a crash never leaves a truncated checkpoint
+	path := s.path(key)
+	if err := ioutil.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return errors.Wrap(err, "could not write checkpoint")
+	}
+	return errors.Wrap(os.Rename(path+".tmp", path), "could not write checkpoint")
+}
+
+// Delete removes the checkpoint file of key
+func (s FileCheckpointStore) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "could not delete checkpoint")
+	}
+	return nil
+}
+
+// CheckpointOptions configure the resumable batched imports
+type CheckpointOptions struct {
+
+	// Key identifies the import, the same key must be used to resume it.
+	// It is required and must differ between imports of different sources,
+	// for instance the name of the imported file.
+	Key string
+
+	// Store persists the checkpoints.
+	// Default to a FileCheckpointStore in the temporary directory.
+	Store CheckpointStore
+}
+
+func (o CheckpointOptions) withDefaults() (CheckpointOptions, error) {
+	if o.Key == "" {
+		// A key shared by unrelated imports would make one resume the other
+		return o, errors.New("a checkpoint key identifying the import is required")
+	}
+	if o.Store == nil {
+		o.Store = FileCheckpointStore{Dir: os.TempDir()}
+	}
+	return o, nil
+}
+
+// AddDocumentsNdjsonFromReaderInBatchesWithCheckpoint is a resumable
+// AddDocumentsNdjsonFromReaderInBatches.
+//
+// A checkpoint is saved after every batch sent. When a checkpoint exists for
+// the key the import resumes after the last sent batch: documents is read
+// from the recorded offset if it implements io.Seeker, otherwise the sent
+// lines are skipped. The update ids of the previous runs are returned first.
+// On error the update ids of the batches already sent are returned with the
+// error. The checkpoint is deleted once the import is complete.
+func (i Index) AddDocumentsNdjsonFromReaderInBatchesWithCheckpoint(documents io.Reader, batchSize int, checkpoint CheckpointOptions, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	checkpoint, err = checkpoint.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	current, err := checkpoint.Store.Load(checkpoint.Key)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		current = &Checkpoint{}
+	}
+	resp = append(resp, current.UpdateIDs...)
+
+	skip := current.Records
+	base := int64(0)
+	if seeker, ok := documents.(io.Seeker); ok && current.Offset > 0 {
+		if _, err := seeker.Seek(current.Offset, io.SeekStart); err != nil {
+			return resp, errors.Wrap(err, "could not seek to checkpoint")
+		}
+		skip = 0
+		base = current.Offset
+	}
+
+	addDocuments := i.documentsAdder(primaryKey...)
+	err = readNdjsonBatches(documents, batchSize, skip, func(lines []string, offset int64) error {
+		update, err := addDocuments(ndjsonBody(lines), contentTypeNDJSON, http.MethodPost)
+		if err != nil {
+			return err
+		}
+		resp = append(resp, *update)
+
+		current.Records += int64(len(lines))
+		current.Offset = base + offset
+		current.UpdateIDs = append(current.UpdateIDs, *update)
+		return checkpoint.Store.Save(checkpoint.Key, current)
+	})
+	if err != nil {
+		return resp, err
+	}
+	return resp, checkpoint.Store.Delete(checkpoint.Key)
+}
+
+// AddDocumentsCsvFromReaderInBatchesWithCheckpoint is a resumable
+// AddDocumentsCsvFromReaderInBatches.
+//
+// It works as AddDocumentsNdjsonFromReaderInBatchesWithCheckpoint, except
+// that CSV records are always skipped by number when resuming, as a record
+// may span several lines.
+func (i Index) AddDocumentsCsvFromReaderInBatchesWithCheckpoint(documents io.Reader, batchSize int, checkpoint CheckpointOptions, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	checkpoint, err = checkpoint.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	current, err := checkpoint.Store.Load(checkpoint.Key)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		current = &Checkpoint{}
+	}
+	resp = append(resp, current.UpdateIDs...)
+
+	addDocuments := i.documentsAdder(primaryKey...)
+	err = readCsvBatches(documents, batchSize, current.Records, func(records [][]string) error {
+		data, err := csvBody(records)
+		if err != nil {
+			return err
+		}
+		update, err := addDocuments(data, contentTypeCSV, http.MethodPost)
+		if err != nil {
+			return err
+		}
+		resp = append(resp, *update)
+
+		// The header record is not a document
+		current.Records += int64(len(records) - 1)
+		current.UpdateIDs = append(current.UpdateIDs, *update)
+		return checkpoint.Store.Save(checkpoint.Key, current)
+	})
+	if err != nil {
+		return resp, err
+	}
+	return resp, checkpoint.Store.Delete(checkpoint.Key)
+}
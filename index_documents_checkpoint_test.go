@@ -0,0 +1,151 @@
+package meilisearch
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	store := FileCheckpointStore{Dir: t.TempDir()}
+
+	gotResp, err := store.Load("import/books")
+	require.NoError(t, err)
+	require.Nil(t, gotResp)
+
+	checkpoint := &Checkpoint{
+		Records:   4,
+		Offset:    128,
+		UpdateIDs: []AsyncUpdateID{{UpdateID: 0}, {UpdateID: 1}},
+	}
+	require.NoError(t, store.Save("import/books", checkpoint))
+
+	gotResp, err = store.Load("import/books")
+	require.NoError(t, err)
+	require.Equal(t, checkpoint, gotResp)
+
+	require.NoError(t, store.Delete("import/books"))
+	require.NoError(t, store.Delete("import/books"))
+	gotResp, err = store.Load("import/books")
+	require.NoError(t, err)
+	require.Nil(t, gotResp)
+}
+
+func TestIndex_AddDocumentsNdjsonFromReaderInBatchesWithCheckpoint(t *testing.T) {
+	// The two first lines of testNdjsonDocuments were sent by a previous run
+	sent := bytes.SplitAfterN(testNdjsonDocuments, []byte("\n"), 3)
+	previous := &Checkpoint{
+		Records:   2,
+		Offset:    int64(len(sent[0]) + len(sent[1])),
+		UpdateIDs: []AsyncUpdateID{{UpdateID: 42}},
+	}
+
+	tests := []struct {
+		name      string
+		documents io.Reader
+	}{
+		{
+			name:      "TestIndexResumeNdjsonWithSeeker",
+			documents: bytes.NewReader(testNdjsonDocuments),
+		},
+		{
+			name:      "TestIndexResumeNdjsonWithoutSeeker",
+			documents: struct{ io.Reader }{bytes.NewReader(testNdjsonDocuments)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := defaultClient
+			i := c.Index("ndjsoncheckpoint")
+			t.Cleanup(cleanup(c))
+
+			store := FileCheckpointStore{Dir: t.TempDir()}
+			require.NoError(t, store.Save("books", previous))
+
+			gotResp, err := i.AddDocumentsNdjsonFromReaderInBatchesWithCheckpoint(tt.documents, 2, CheckpointOptions{
+				Key:   "books",
+				Store: store,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []AsyncUpdateID{{UpdateID: 42}, {UpdateID: 0}, {UpdateID: 1}}, gotResp)
+			testWaitForPendingBatchUpdate(t, i, gotResp[1:])
+
+			wantDocs := testParseNdjsonDocuments(t, bytes.NewReader(testNdjsonDocuments))[2:]
+			var documents []map[string]interface{}
+			err = i.GetDocuments(&DocumentsRequest{}, &documents)
+			require.NoError(t, err)
+			require.Equal(t, wantDocs, documents)
+
+			checkpoint, err := store.Load("books")
+			require.NoError(t, err)
+			require.Nil(t, checkpoint)
+		})
+	}
+}
+
+func TestIndex_AddDocumentsCsvFromReaderInBatchesWithCheckpoint(t *testing.T) {
+	c := defaultClient
+	i := c.Index("csvcheckpoint")
+	t.Cleanup(cleanup(c))
+
+	store := FileCheckpointStore{Dir: t.TempDir()}
+	require.NoError(t, store.Save("books", &Checkpoint{
+		Records:   3,
+		UpdateIDs: []AsyncUpdateID{{UpdateID: 7}, {UpdateID: 8}},
+	}))
+
+	gotResp, err := i.AddDocumentsCsvFromReaderInBatchesWithCheckpoint(bytes.NewReader(testCsvDocuments), 1, CheckpointOptions{
+		Key:   "books",
+		Store: store,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []AsyncUpdateID{{UpdateID: 7}, {UpdateID: 8}, {UpdateID: 0}, {UpdateID: 1}}, gotResp)
+	testWaitForPendingBatchUpdate(t, i, gotResp[2:])
+
+	wantDocs := testParseCsvDocuments(t, bytes.NewReader(testCsvDocuments))[3:]
+	var documents []map[string]interface{}
+	err = i.GetDocuments(&DocumentsRequest{}, &documents)
+	require.NoError(t, err)
+	require.Equal(t, wantDocs, documents)
+}
+
+func TestIndex_AddDocumentsInBatchesWithCheckpointKey(t *testing.T) {
+	i := defaultClient.Index("checkpointkey")
+	store := FileCheckpointStore{Dir: t.TempDir()}
+
+	_, err := i.AddDocumentsNdjsonFromReaderInBatchesWithCheckpoint(bytes.NewReader(testNdjsonDocuments), 2, CheckpointOptions{Store: store})
+	require.Error(t, err)
+	_, err = i.AddDocumentsCsvFromReaderInBatchesWithCheckpoint(bytes.NewReader(testCsvDocuments), 2, CheckpointOptions{Store: store})
+	require.Error(t, err)
+}
+
+func TestIndex_AddDocumentsNdjsonFromReaderInBatchesWithCheckpointOtherImport(t *testing.T) {
+	c := defaultClient
+	i := c.Index("ndjsoncheckpoint")
+	t.Cleanup(cleanup(c))
+
+	// An earlier import of another file into the same index was interrupted
+	store := FileCheckpointStore{Dir: t.TempDir()}
+	require.NoError(t, store.Save("books-2021.ndjson", &Checkpoint{
+		Records:   2,
+		UpdateIDs: []AsyncUpdateID{{UpdateID: 42}},
+	}))
+
+	gotResp, err := i.AddDocumentsNdjsonFromReaderInBatchesWithCheckpoint(bytes.NewReader(testNdjsonDocuments), 2, CheckpointOptions{
+		Key:   "books-2022.ndjson",
+		Store: store,
+	})
+	require.NoError(t, err)
+	testWaitForPendingBatchUpdate(t, i, gotResp)
+
+	wantDocs := testParseNdjsonDocuments(t, bytes.NewReader(testNdjsonDocuments))
+	var documents []map[string]interface{}
+	require.NoError(t, i.GetDocuments(&DocumentsRequest{}, &documents))
+	require.Equal(t, wantDocs, documents)
+
+	checkpoint, err := store.Load("books-2021.ndjson")
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint)
+}
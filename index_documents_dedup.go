@@ -0,0 +1,294 @@
+package meilisearch
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DuplicatePolicy decides which copy of a document is sent when a batch holds
+// several documents with the same primary key
+type DuplicatePolicy string
+
+const (
+	// DuplicateLastWins sends the last copy of the document
+	DuplicateLastWins DuplicatePolicy = "lastWins"
+	// DuplicateFirstWins sends the first copy of the document
+	DuplicateFirstWins DuplicatePolicy = "firstWins"
+	// DuplicateMerge sends the fields of every copy, the fields of later
+	// copies replacing those of earlier ones. Empty CSV cells do not replace
+	// a value.
+	DuplicateMerge DuplicatePolicy = "merge"
+)
+
+// DeduplicateOptions configure the deduplicated batched imports
+type DeduplicateOptions struct {
+
+	// PrimaryKey is optional. When empty, the primary key of the index is
+	// used, or else the primary key is inferred from the documents as
+	// Meilisearch does.
+	PrimaryKey string
+
+	// Policy chooses the copy sent for a duplicated document.
+	// Default to DuplicateLastWins.
+	Policy DuplicatePolicy
+}
+
+// DeduplicateResult is the result of a deduplicated batched import
+type DeduplicateResult struct {
+	Updates []AsyncUpdateID
+
+	// Collapsed is the number of duplicated documents that were not sent
+	Collapsed int
+}
+
+// AddDocumentsInBatchesDeduplicated is AddDocumentsInBatches, except that the
+// documents sharing a primary key inside a batch are collapsed into one
+// document according to opts.Policy. Duplicates in different batches are
+// all sent. Documents without a primary key are sent unchanged.
+func (i Index) AddDocumentsInBatchesDeduplicated(documentsPtr interface{}, batchSize int, opts DeduplicateOptions) (resp *DeduplicateResult, err error) {
+	if err := opts.validate(batchSize); err != nil {
+		return nil, err
+	}
+	documents, err := normalizeDocuments(i.client.jsonCodec(), documentsPtr)
+	if err != nil {
+		return nil, err
+	}
+	if len(documents) == 0 {
+		return &DeduplicateResult{}, nil
+	}
+	fields := make([]string, 0, len(documents[0]))
+	for field := range documents[0] {
+		fields = append(fields, field)
+	}
+	key, err := i.deduplicatePrimaryKey(opts, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &DeduplicateResult{}
+	addDocuments := i.documentsAdder(opts.primaryKey()...)
+	for start := 0; start < len(documents); start += batchSize {
+		end := start + batchSize
+		if end > len(documents) {
+			end = len(documents)
+		}
+		batch := documents[start:end]
+
+		keys := make([]string, len(batch))
+		for j, document := range batch {
+			keys[j], _ = documentID(document, key)
+		}
+		kept := collapseDuplicates(keys, opts.Policy, func(into, from int) {
+			for field, value := range batch[from] {
+				batch[into][field] = value
+			}
+		})
+		resp.Collapsed += len(batch) - len(kept)
+
+		deduplicated := make([]map[string]interface{}, len(kept))
+		for j, position := range kept {
+			deduplicated[j] = batch[position]
+		}
+		update, err := addDocuments(deduplicated, contentTypeJSON, http.MethodPost)
+		if err != nil {
+			return resp, err
+		}
+		resp.Updates = append(resp.Updates, *update)
+	}
+	return resp, nil
+}
+
+// AddDocumentsNdjsonFromReaderInBatchesDeduplicated is
+// AddDocumentsNdjsonFromReaderInBatches with the deduplication of
+// AddDocumentsInBatchesDeduplicated. Lines are sent unchanged unless they
+// are merged.
+func (i Index) AddDocumentsNdjsonFromReaderInBatchesDeduplicated(documents io.Reader, batchSize int, opts DeduplicateOptions) (resp *DeduplicateResult, err error) {
+	if err := opts.validate(batchSize); err != nil {
+		return nil, err
+	}
+	key := ""
+	resp = &DeduplicateResult{}
+	addDocuments := i.documentsAdder(opts.primaryKey()...)
+	err = readNdjsonBatches(documents, batchSize, 0, func(lines []string, _ int64) error {
+		batch := make([]map[string]interface{}, len(lines))
+		for j, line := range lines {
+			document, err := decodeDocument(i.client.jsonCodec(), []byte(line))
+			if err != nil {
+				return err
+			}
+			batch[j] = document
+		}
+		if key == "" {
+			fields := make([]string, 0, len(batch[0]))
+			for field := range batch[0] {
+				fields = append(fields, field)
+			}
+			var err error
+			if key, err = i.deduplicatePrimaryKey(opts, fields); err != nil {
+				return err
+			}
+		}
+
+		keys := make([]string, len(batch))
+		for j, document := range batch {
+			keys[j], _ = documentID(document, key)
+		}
+		var mergeErr error
+		kept := collapseDuplicates(keys, opts.Policy, func(into, from int) {
+			for field, value := range batch[from] {
+				batch[into][field] = value
+			}
+			data, err := i.client.jsonCodec().Marshal(batch[into])
+			if err != nil && mergeErr == nil {
+				mergeErr = errors.Wrap(err, "could not encode merged document")
+			}
+			lines[into] = string(data)
+		})
+		if mergeErr != nil {
+			return mergeErr
+		}
+		resp.Collapsed += len(lines) - len(kept)
+
+		deduplicated := make([]string, len(kept))
+		for j, position := range kept {
+			deduplicated[j] = lines[position]
+		}
+		update, err := addDocuments(ndjsonBody(deduplicated), contentTypeNDJSON, http.MethodPost)
+		if err != nil {
+			return err
+		}
+		resp.Updates = append(resp.Updates, *update)
+		return nil
+	})
+	return resp, err
+}
+
+// AddDocumentsCsvFromReaderInBatchesDeduplicated is
+// AddDocumentsCsvFromReaderInBatches with the deduplication of
+// AddDocumentsInBatchesDeduplicated.
+func (i Index) AddDocumentsCsvFromReaderInBatchesDeduplicated(documents io.Reader, batchSize int, opts DeduplicateOptions) (resp *DeduplicateResult, err error) {
+	if err := opts.validate(batchSize); err != nil {
+		return nil, err
+	}
+	column := -1
+	resp = &DeduplicateResult{}
+	addDocuments := i.documentsAdder(opts.primaryKey()...)
+	err = readCsvBatches(documents, batchSize, 0, func(records [][]string) error {
+		header, batch := records[0], records[1:]
+		if column < 0 {
+			// Type annotations of the header ("price:number") are not part of the field name
+			fields := make([]string, len(header))
+			for j, field := range header {
+				fields[j] = strings.SplitN(field, ":", 2)[0]
+			}
+			key, err := i.deduplicatePrimaryKey(opts, fields)
+			if err != nil {
+				return err
+			}
+			for j, field := range fields {
+				if field == key {
+					column = j
+				}
+			}
+			if column < 0 {
+				return fmt.Errorf("primary key %q is not a column of the CSV header", key)
+			}
+		}
+
+		keys := make([]string, len(batch))
+		for j, record := range batch {
+			if column < len(record) {
+				keys[j] = record[column]
+			}
+		}
+		kept := collapseDuplicates(keys, opts.Policy, func(into, from int) {
+			for j, value := range batch[from] {
+				if value != "" && j < len(batch[into]) {
+					batch[into][j] = value
+				}
+			}
+		})
+		resp.Collapsed += len(batch) - len(kept)
+
+		deduplicated := make([][]string, 0, len(kept)+1)
+		deduplicated = append(deduplicated, header)
+		for _, position := range kept {
+			deduplicated = append(deduplicated, batch[position])
+		}
+		data, err := csvBody(deduplicated)
+		if err != nil {
+			return err
+		}
+		update, err := addDocuments(data, contentTypeCSV, http.MethodPost)
+		if err != nil {
+			return err
+		}
+		resp.Updates = append(resp.Updates, *update)
+		return nil
+	})
+	return resp, err
+}
+
+func (o DeduplicateOptions) validate(batchSize int) error {
+	if batchSize <= 0 {
+		return fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+	switch o.Policy {
+	case "", DuplicateLastWins, DuplicateFirstWins, DuplicateMerge:
+		return nil
+	default:
+		return fmt.Errorf("unknown duplicate policy: %q", o.Policy)
+	}
+}
+
+func (o DeduplicateOptions) primaryKey() []string {
+	if o.PrimaryKey == "" {
+		return nil
+	}
+	return []string{o.PrimaryKey}
+}
+
+// deduplicatePrimaryKey returns the primary key used to find duplicates:
+// opts.PrimaryKey, the primary key of the index or the one inferred from
+// fields.
+func (i Index) deduplicatePrimaryKey(opts DeduplicateOptions, fields []string) (string, error) {
+	key, err := i.validationPrimaryKey(opts.primaryKey()...)
+	if err != nil || key != "" {
+		return key, err
+	}
+	return inferPrimaryKey(fields)
+}
+
+// collapseDuplicates returns the positions of the documents to send, in the
+// order of the first copy of every document. Empty keys are never
+// duplicates. With DuplicateMerge, merge is called with the position of the
+// first copy and of every later copy, in order.
+func collapseDuplicates(keys []string, policy DuplicatePolicy, merge func(into, from int)) []int {
+	kept := make([]int, 0, len(keys))
+	// Index in kept of the copy retained for every key
+	seen := make(map[string]int, len(keys))
+	for position, key := range keys {
+		if key == "" {
+			kept = append(kept, position)
+			continue
+		}
+		j, ok := seen[key]
+		if !ok {
+			seen[key] = len(kept)
+			kept = append(kept, position)
+			continue
+		}
+		switch policy {
+		case DuplicateFirstWins:
+		case DuplicateMerge:
+			merge(kept[j], position)
+		default:
+			kept[j] = position
+		}
+	}
+	return kept
+}
@@ -0,0 +1,184 @@
+package meilisearch
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_collapseDuplicates(t *testing.T) {
+	keys := []string{"1", "2", "1", "", "2", "1", ""}
+	tests := []struct {
+		name       string
+		policy     DuplicatePolicy
+		wantKept   []int
+		wantMerges [][2]int
+	}{
+		{name: "TestCollapseDefaultPolicy", policy: "", wantKept: []int{5, 4, 3, 6}},
+		{name: "TestCollapseLastWins", policy: DuplicateLastWins, wantKept: []int{5, 4, 3, 6}},
+		{name: "TestCollapseFirstWins", policy: DuplicateFirstWins, wantKept: []int{0, 1, 3, 6}},
+		{name: "TestCollapseMerge", policy: DuplicateMerge, wantKept: []int{0, 1, 3, 6}, wantMerges: [][2]int{{0, 2}, {1, 4}, {0, 5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var merges [][2]int
+			got := collapseDuplicates(keys, tt.policy, func(into, from int) {
+				merges = append(merges, [2]int{into, from})
+			})
+			require.Equal(t, tt.wantKept, got)
+			require.Equal(t, tt.wantMerges, merges)
+		})
+	}
+}
+
+func TestIndex_AddDocumentsInBatchesDeduplicated(t *testing.T) {
+	documents := []map[string]interface{}{
+		{"book_id": 123, "title": "Pride and Prejudice"},
+		{"book_id": 456, "title": "Le Petit Prince"},
+		{"book_id": 123, "year": 1813},
+		{"book_id": 1, "title": "Alice In Wonderland"},
+		{"book_id": 1, "title": "Alice's Adventures in Wonderland"},
+	}
+	tests := []struct {
+		name          string
+		opts          DeduplicateOptions
+		batchSize     int
+		wantCollapsed int
+		wantDocs      []map[string]interface{}
+	}{
+		{
+			name:          "TestIndexDeduplicateLastWins",
+			opts:          DeduplicateOptions{PrimaryKey: "book_id"},
+			batchSize:     5,
+			wantCollapsed: 2,
+			wantDocs: []map[string]interface{}{
+				{"book_id": float64(1), "title": "Alice's Adventures in Wonderland"},
+				{"book_id": float64(123), "year": float64(1813)},
+				{"book_id": float64(456), "title": "Le Petit Prince"},
+			},
+		},
+		{
+			name:          "TestIndexDeduplicateFirstWinsWithInferredPrimaryKey",
+			opts:          DeduplicateOptions{Policy: DuplicateFirstWins},
+			batchSize:     5,
+			wantCollapsed: 2,
+			wantDocs: []map[string]interface{}{
+				{"book_id": float64(1), "title": "Alice In Wonderland"},
+				{"book_id": float64(123), "title": "Pride and Prejudice"},
+				{"book_id": float64(456), "title": "Le Petit Prince"},
+			},
+		},
+		{
+			name:          "TestIndexDeduplicateMerge",
+			opts:          DeduplicateOptions{PrimaryKey: "book_id", Policy: DuplicateMerge},
+			batchSize:     5,
+			wantCollapsed: 2,
+			wantDocs: []map[string]interface{}{
+				{"book_id": float64(1), "title": "Alice's Adventures in Wonderland"},
+				{"book_id": float64(123), "title": "Pride and Prejudice", "year": float64(1813)},
+				{"book_id": float64(456), "title": "Le Petit Prince"},
+			},
+		},
+		{
+			name:          "TestIndexDeduplicateOnlyInsideBatches",
+			opts:          DeduplicateOptions{PrimaryKey: "book_id", Policy: DuplicateFirstWins},
+			batchSize:     2,
+			wantCollapsed: 0,
+			wantDocs: []map[string]interface{}{
+				{"book_id": float64(1), "title": "Alice's Adventures in Wonderland"},
+				{"book_id": float64(123), "year": float64(1813)},
+				{"book_id": float64(456), "title": "Le Petit Prince"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := defaultClient
+			i := c.Index("deduplicate")
+			t.Cleanup(cleanup(c))
+
+			gotResp, err := i.AddDocumentsInBatchesDeduplicated(documents, tt.batchSize, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCollapsed, gotResp.Collapsed)
+			testWaitForPendingBatchUpdate(t, i, gotResp.Updates)
+
+			var got []map[string]interface{}
+			err = i.GetDocuments(&DocumentsRequest{}, &got)
+			require.NoError(t, err)
+			sort.Slice(got, func(a, b int) bool {
+				return got[a]["book_id"].(float64) < got[b]["book_id"].(float64)
+			})
+			require.Equal(t, tt.wantDocs, got)
+		})
+	}
+
+	_, err := defaultClient.Index("deduplicate").AddDocumentsInBatchesDeduplicated(documents, 5, DeduplicateOptions{Policy: "random"})
+	require.Error(t, err)
+}
+
+func TestIndex_DeduplicatedBatchSize(t *testing.T) {
+	i := defaultClient.Index("deduplicate")
+	for _, batchSize := range []int{0, -1} {
+		_, err := i.AddDocumentsInBatchesDeduplicated([]map[string]interface{}{{"id": 1}}, batchSize, DeduplicateOptions{})
+		require.EqualError(t, err, fmt.Sprintf("batch size must be positive, got %d", batchSize))
+		_, err = i.AddDocumentsNdjsonFromReaderInBatchesDeduplicated(strings.NewReader(`{"id":1}`), batchSize, DeduplicateOptions{})
+		require.Error(t, err)
+		_, err = i.AddDocumentsCsvFromReaderInBatchesDeduplicated(strings.NewReader("id\n1\n"), batchSize, DeduplicateOptions{})
+		require.Error(t, err)
+	}
+}
+
+func TestIndex_AddDocumentsNdjsonFromReaderInBatchesDeduplicated(t *testing.T) {
+	documents := []byte(`{"id": 1, "name": "Alice In Wonderland"}
+{"id": 2, "name": "Pride and Prejudice"}
+{"id": 1, "genre": "Tale"}
+`)
+
+	c := defaultClient
+	i := c.Index("ndjsondeduplicate")
+	t.Cleanup(cleanup(c))
+
+	gotResp, err := i.AddDocumentsNdjsonFromReaderInBatchesDeduplicated(bytes.NewReader(documents), 10, DeduplicateOptions{
+		Policy: DuplicateMerge,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, gotResp.Collapsed)
+	testWaitForPendingBatchUpdate(t, i, gotResp.Updates)
+
+	var document map[string]interface{}
+	err = i.GetDocument("1", &document)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"id": float64(1), "name": "Alice In Wonderland", "genre": "Tale"}, document)
+}
+
+func TestIndex_AddDocumentsCsvFromReaderInBatchesDeduplicated(t *testing.T) {
+	documents := []byte("id:number,name,genre\n" +
+		"1,Alice In Wonderland,Tale\n" +
+		"2,Pride and Prejudice,Romance\n" +
+		"1,Alice's Adventures in Wonderland,\n" +
+		"2,,Classic\n")
+
+	c := defaultClient
+	i := c.Index("csvdeduplicate")
+	t.Cleanup(cleanup(c))
+
+	gotResp, err := i.AddDocumentsCsvFromReaderInBatchesDeduplicated(bytes.NewReader(documents), 10, DeduplicateOptions{
+		PrimaryKey: "id",
+		Policy:     DuplicateMerge,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, gotResp.Collapsed)
+	testWaitForPendingBatchUpdate(t, i, gotResp.Updates)
+
+	var got []map[string]interface{}
+	err = i.GetDocuments(&DocumentsRequest{}, &got)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []map[string]interface{}{
+		{"id": float64(1), "name": "Alice's Adventures in Wonderland", "genre": "Tale"},
+		{"id": float64(2), "name": "Pride and Prejudice", "genre": "Classic"},
+	}, got)
+}
@@ -0,0 +1,393 @@
+package meilisearch
+
+import (
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EncodeDocumentsCsv writes documents to w as an RFC 4180 CSV file with a
+// header row, ready to be sent with AddDocumentsCsv.
+//
+// documents is a slice, an array or a channel of structs or pointers to
+// structs. A channel is read until it is closed. Columns are named after the
+// json tag of the exported fields, or after the field name. A csv tag
+// overrides the column name and may carry a Meilisearch type annotation
+// (`csv:"price:number"`); numeric fields are annotated with ":number" when no
+// annotation is given. Fields tagged "-" are skipped and embedded structs are
+// flattened as with encoding/json.
+//
+// Strings, numbers and booleans are written as is, encoding.TextMarshaler
+// values as text, nil pointers as empty cells and any other value as JSON.
+func EncodeDocumentsCsv(w io.Writer, documents interface{}) error {
+	source, err := newDocumentsSource(documents)
+	if err != nil {
+		return err
+	}
+	encoder, err := newCsvStructEncoder(source.elem, DefaultJSONCodec())
+	if err != nil {
+		return err
+	}
+	return encoder.encode(w, source)
+}
+
+// EncodeDocumentsNdjson writes documents to w as NDJSON, one JSON document
+// per line, ready to be sent with AddDocumentsNdjson.
+//
+// documents is a slice, an array or a channel of values encoded with
+// encoding/json. A channel is read until it is closed.
+func EncodeDocumentsNdjson(w io.Writer, documents interface{}) error {
+	source, err := newDocumentsSource(documents)
+	if err != nil {
+		return err
+	}
+	return encodeNdjson(w, source, DefaultJSONCodec())
+}
+
+// AddDocumentsCsvFromStructsInBatches encodes documents with EncodeDocumentsCsv
+// and sends them in batches of batchSize as AddDocumentsCsvFromReaderInBatches
+// does. Documents are encoded while batches are sent, so a channel of
+// documents is never held in memory at once. Values written as JSON are
+// encoded with the JSONCodec of the client.
+//
+// If a batch fails the remaining documents of a channel are not read anymore.
+func (i Index) AddDocumentsCsvFromStructsInBatches(documents interface{}, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	source, err := newDocumentsSource(documents)
+	if err != nil {
+		return nil, err
+	}
+	encoder, err := newCsvStructEncoder(source.elem, i.client.jsonCodec())
+	if err != nil {
+		return nil, err
+	}
+
+	r := pipeDocuments(func(w io.Writer) error {
+		return encoder.encode(w, source)
+	})
+	defer r.Close()
+	return i.addDocumentsCsvFromReaderInBatches(r, batchSize, http.MethodPost, primaryKey...)
+}
+
+// AddDocumentsNdjsonFromStructsInBatches encodes documents with
+// EncodeDocumentsNdjson and sends them in batches of batchSize as
+// AddDocumentsNdjsonFromReaderInBatches does. Documents are encoded with the
+// JSONCodec of the client.
+//
+// If a batch fails the remaining documents of a channel are not read anymore.
+func (i Index) AddDocumentsNdjsonFromStructsInBatches(documents interface{}, batchSize int, primaryKey ...string) (resp []AsyncUpdateID, err error) {
+	source, err := newDocumentsSource(documents)
+	if err != nil {
+		return nil, err
+	}
+
+	r := pipeDocuments(func(w io.Writer) error {
+		return encodeNdjson(w, source, i.client.jsonCodec())
+	})
+	defer r.Close()
+	return i.addDocumentsNdjsonFromReaderInBatches(r, batchSize, http.MethodPost, primaryKey...)
+}
+
+// pipeDocuments runs encode in a goroutine and returns the encoded content.
+// Closing the returned reader stops the encoding.
+func pipeDocuments(encode func(w io.Writer) error) io.ReadCloser {
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(encode(w))
+	}()
+	return r
+}
+
+// documentsSource iterates over the documents of a slice, an array or a channel
+type documentsSource struct {
+	documents reflect.Value
+	elem      reflect.Type
+	position  int
+}
+
+func newDocumentsSource(documents interface{}) (*documentsSource, error) {
+	v := reflect.ValueOf(documents)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+	case reflect.Chan:
+		if v.Type().ChanDir()&reflect.RecvDir == 0 {
+			return nil, errors.New("documents channel is send-only")
+		}
+	default:
+		return nil, fmt.Errorf("documents must be a slice, an array or a channel, got %T", documents)
+	}
+	return &documentsSource{documents: v, elem: v.Type().Elem()}, nil
+}
+
+// next returns the next document, false once every document has been read
+func (s *documentsSource) next() (reflect.Value, bool) {
+	if s.documents.Kind() == reflect.Chan {
+		return s.documents.Recv()
+	}
+	if s.position >= s.documents.Len() {
+		return reflect.Value{}, false
+	}
+	s.position++
+	return s.documents.Index(s.position - 1), true
+}
+
+func encodeNdjson(w io.Writer, source *documentsSource, codec JSONCodec) error {
+	for {
+		document, ok := source.next()
+		if !ok {
+			return nil
+		}
+		data, err := codec.Marshal(document.Interface())
+		if err != nil {
+			return errors.Wrap(err, "could not encode NDJSON document")
+		}
+		if _, err := w.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+}
+
+// csvStructColumn is a CSV column read from a struct field
+type csvStructColumn struct {
+	header string
+	index  []int
+}
+
+// csvStructEncoder writes structs of a given type as CSV records
+type csvStructEncoder struct {
+	elem    reflect.Type
+	columns []csvStructColumn
+	codec   JSONCodec
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+func newCsvStructEncoder(elem reflect.Type, codec JSONCodec) (*csvStructEncoder, error) {
+	structType := elem
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("CSV documents must be structs or pointers to structs, got %s", elem)
+	}
+
+	e := &csvStructEncoder{elem: elem, codec: codec}
+	if err := e.addColumns(structType, nil, map[string]bool{}); err != nil {
+		return nil, err
+	}
+	if len(e.columns) == 0 {
+		return nil, fmt.Errorf("%s has no exported field to encode as CSV", structType)
+	}
+	return e, nil
+}
+
+func (e *csvStructEncoder) addColumns(t reflect.Type, index []int, names map[string]bool) error {
+	for j := 0; j < t.NumField(); j++ {
+		field := t.Field(j)
+		fieldIndex := append(append([]int{}, index...), j)
+
+		name, annotation, skip := csvFieldName(field)
+		if skip {
+			continue
+		}
+
+		// Flatten embedded structs without an explicit name, as encoding/json does
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if err := e.addColumns(fieldType, fieldIndex, names); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		if names[name] {
+			return fmt.Errorf("CSV column %q is declared more than once in %s", name, t)
+		}
+		names[name] = true
+
+		if annotation == "" && isCsvNumber(field.Type) {
+			annotation = "number"
+		}
+		header := name
+		if annotation != "" {
+			header += ":" + annotation
+		}
+		e.columns = append(e.columns, csvStructColumn{header: header, index: fieldIndex})
+	}
+	return nil
+}
+
+// csvFieldName returns the column name and type annotation of a field from
+// its csv or json tag
+func csvFieldName(field reflect.StructField) (name string, annotation string, skip bool) {
+	if tag, ok := field.Tag.Lookup("csv"); ok {
+		if tag == "-" {
+			return "", "", true
+		}
+		if j := strings.LastIndex(tag, ":"); j >= 0 {
+			return tag[:j], tag[j+1:], false
+		}
+		return tag, "", false
+	}
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if tag == "-" {
+			return "", "", true
+		}
+		return strings.Split(tag, ",")[0], "", false
+	}
+	return "", "", false
+}
+
+func isCsvNumber(t reflect.Type) bool {
+	if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return false
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func (e *csvStructEncoder) encode(w io.Writer, source *documentsSource) error {
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true // Keep output RFC 4180 compliant
+
+	header := make([]string, len(e.columns))
+	for j, column := range e.columns {
+		header[j] = column.header
+	}
+	if err := cw.Write(header); err != nil {
+		return errors.Wrap(err, "could not write CSV header")
+	}
+
+	for position := 0; ; position++ {
+		document, ok := source.next()
+		if !ok {
+			break
+		}
+		record, err := e.record(document)
+		if err != nil {
+			return errors.Wrapf(err, "could not encode document %d", position)
+		}
+		if err := cw.Write(record); err != nil {
+			return errors.Wrap(err, "could not write CSV record")
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (e *csvStructEncoder) record(document reflect.Value) ([]string, error) {
+	if document.Kind() == reflect.Ptr {
+		if document.IsNil() {
+			return nil, errors.New("document is nil")
+		}
+		document = document.Elem()
+	}
+
+	record := make([]string, len(e.columns))
+	for j, column := range e.columns {
+		value, ok := csvFieldByIndex(document, column.index)
+		if !ok {
+			// Field of a nil embedded struct
+			continue
+		}
+		cell, err := e.cell(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "field %q", column.header)
+		}
+		record[j] = cell
+	}
+	return record, nil
+}
+
+// csvFieldByIndex is reflect.Value.FieldByIndex, without panicking on nil
+// embedded pointers
+func csvFieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for j, x := range index {
+		if j > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func (e *csvStructEncoder) cell(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		if isNilSliceOrMap(v.Elem()) {
+			return "", nil
+		}
+		return csvCellValue(e.codec, v.Interface())
+	}
+	// Nil values are empty cells, csvCellValue would write them as null
+	if (v.Kind() == reflect.Ptr && v.IsNil()) || isNilSliceOrMap(v) {
+		return "", nil
+	}
+
+	marshaler, ok := v.Interface().(encoding.TextMarshaler)
+	if !ok && v.CanAddr() {
+		marshaler, ok = v.Addr().Interface().(encoding.TextMarshaler)
+	}
+	if ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(text), nil
+	}
+
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	default:
+		return csvCellValue(e.codec, v.Interface())
+	}
+}
+
+func isNilSliceOrMap(v reflect.Value) bool {
+	return (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil()
+}
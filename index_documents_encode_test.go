@@ -0,0 +1,150 @@
+package meilisearch
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type docTestEncodeBase struct {
+	ID int `json:"id"`
+}
+
+type docTestEncode struct {
+	docTestEncodeBase
+	Title     string    `json:"title"`
+	Price     float64   `csv:"price"`
+	Stock     *int      `json:"stock,omitempty"`
+	Year      int32     `csv:"year:string"`
+	Tags      []string  `json:"tags"`
+	Available bool      `json:"available"`
+	Published time.Time `json:"published"`
+	Internal  string    `json:"-"`
+	secret    string
+}
+
+func TestEncodeDocumentsCsv(t *testing.T) {
+	stock := 3
+	published := time.Date(1865, time.November, 26, 0, 0, 0, 0, time.UTC)
+	documents := []docTestEncode{
+		{
+			docTestEncodeBase: docTestEncodeBase{ID: 1},
+			Title:             "Alice In Wonderland",
+			Price:             9.5,
+			Stock:             &stock,
+			Year:              1865,
+			Tags:              []string{"tale", "fantasy"},
+			Available:         true,
+			Published:         published,
+			Internal:          "ignored",
+			secret:            "ignored",
+		},
+		{
+			docTestEncodeBase: docTestEncodeBase{ID: 2},
+			Title:             "Pride, and \"Prejudice\"",
+		},
+	}
+
+	b := new(bytes.Buffer)
+	err := EncodeDocumentsCsv(b, documents)
+	require.NoError(t, err)
+	require.Equal(t, "id:number,title,price:number,stock:number,year:string,tags,available,published\r\n"+
+		"1,Alice In Wonderland,9.5,3,1865,\"[\"\"tale\"\",\"\"fantasy\"\"]\",true,1865-11-26T00:00:00Z\r\n"+
+		"2,\"Pride, and \"\"Prejudice\"\"\",0,,0,,false,0001-01-01T00:00:00Z\r\n", b.String())
+
+	// Pointers are encoded as their values
+	b.Reset()
+	err = EncodeDocumentsCsv(b, []*docTestEncode{&documents[0], &documents[1]})
+	require.NoError(t, err)
+	require.Contains(t, b.String(), "1,Alice In Wonderland,9.5,3,1865")
+
+	err = EncodeDocumentsCsv(b, []*docTestEncode{nil})
+	require.Error(t, err)
+
+	err = EncodeDocumentsCsv(b, []string{"Alice In Wonderland"})
+	require.Error(t, err)
+
+	err = EncodeDocumentsCsv(b, docTestEncode{})
+	require.Error(t, err)
+}
+
+func TestEncodeDocumentsCsvNilValues(t *testing.T) {
+	type document struct {
+		ID       int                    `json:"id"`
+		Tags     []string               `json:"tags"`
+		Metadata map[string]interface{} `json:"metadata"`
+		Extra    interface{}            `json:"extra"`
+	}
+
+	b := new(bytes.Buffer)
+	err := EncodeDocumentsCsv(b, []document{
+		{ID: 1},
+		{ID: 2, Extra: []string(nil)},
+		{ID: 3, Tags: []string{}, Metadata: map[string]interface{}{"a": 1}, Extra: map[string]int(nil)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "id:number,tags,metadata,extra\r\n"+
+		"1,,,\r\n"+
+		"2,,,\r\n"+
+		"3,[],\"{\"\"a\"\":1}\",\r\n", b.String())
+}
+
+func TestEncodeDocumentsNdjson(t *testing.T) {
+	documents := make(chan docTest, 2)
+	documents <- docTest{ID: "123", Name: "Pride and Prejudice"}
+	documents <- docTest{ID: "456", Name: "Le Petit Prince"}
+	close(documents)
+
+	b := new(bytes.Buffer)
+	err := EncodeDocumentsNdjson(b, documents)
+	require.NoError(t, err)
+	require.Equal(t, "{\"id\":\"123\",\"name\":\"Pride and Prejudice\"}\n{\"id\":\"456\",\"name\":\"Le Petit Prince\"}\n", b.String())
+}
+
+func TestIndex_AddDocumentsCsvFromStructsInBatches(t *testing.T) {
+	books := []docTestBooks{
+		{BookID: 123, Title: "Pride and Prejudice", Tag: "Romance", Year: 1813},
+		{BookID: 456, Title: "Le Petit Prince", Tag: "Tale", Year: 1943},
+		{BookID: 1, Title: "Alice In Wonderland", Tag: "Tale", Year: 1865},
+	}
+
+	c := defaultClient
+	i := c.Index("csvstructs")
+	t.Cleanup(cleanup(c))
+
+	gotResp, err := i.AddDocumentsCsvFromStructsInBatches(books, 2, "book_id")
+	require.NoError(t, err)
+	require.Len(t, gotResp, 2)
+	testWaitForPendingBatchUpdate(t, i, gotResp)
+
+	var documents []docTestBooks
+	err = i.GetDocuments(&DocumentsRequest{}, &documents)
+	require.NoError(t, err)
+	require.ElementsMatch(t, books, documents)
+}
+
+func TestIndex_AddDocumentsNdjsonFromStructsInBatches(t *testing.T) {
+	books := make(chan docTestBooks)
+	go func() {
+		defer close(books)
+		for j := 1; j <= 5; j++ {
+			books <- docTestBooks{BookID: j, Title: "Book", Tag: "Tale", Year: 1900 + j}
+		}
+	}()
+
+	c := defaultClient
+	i := c.Index("ndjsonstructs")
+	t.Cleanup(cleanup(c))
+
+	gotResp, err := i.AddDocumentsNdjsonFromStructsInBatches(books, 2, "book_id")
+	require.NoError(t, err)
+	require.Len(t, gotResp, 3)
+	testWaitForPendingBatchUpdate(t, i, gotResp)
+
+	var documents []docTestBooks
+	err = i.GetDocuments(&DocumentsRequest{}, &documents)
+	require.NoError(t, err)
+	require.Len(t, documents, 5)
+}
@@ -3,6 +3,7 @@ package meilisearch
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"io"
@@ -716,6 +717,97 @@ func TestIndex_AddDocumentsNdjsonInBatches(t *testing.T) {
 	}
 }
 
+func TestIndex_AddDocumentsJSONFromReaderInBatches(t *testing.T) {
+	type args struct {
+		uid       string
+		client    *Client
+		batchSize int
+		documents string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantResp []AsyncUpdateID
+		wantErr  bool
+	}{
+		{
+			name: "TestIndexBasicAddDocumentsJSONFromReaderInBatches",
+			args: args{
+				uid:       "jsonbatch",
+				client:    defaultClient,
+				batchSize: 2,
+				documents: `[
+					{"id": 1, "name": "Alice In Wonderland"},
+					{"id": 2, "name": "Pride and Prejudice"},
+					{"id": 3, "name": "Le Petit Prince"},
+					{"id": 4, "name": "The Great Gatsby"},
+					{"id": 5, "name": "Don Quixote"}
+				]`,
+			},
+			wantResp: []AsyncUpdateID{
+				{UpdateID: 0},
+				{UpdateID: 1},
+				{UpdateID: 2},
+			},
+		},
+		{
+			name: "TestIndexAddDocumentsJSONFromReaderInBatchesWithCustomClient",
+			args: args{
+				uid:       "jsonbatch",
+				client:    customClient,
+				batchSize: 10,
+				documents: `[{"id": 1, "name": "Alice In Wonderland"}, {"id": 2, "name": "Pride and Prejudice"}]`,
+			},
+			wantResp: []AsyncUpdateID{
+				{UpdateID: 0},
+			},
+		},
+		{
+			name: "TestIndexAddDocumentsJSONFromReaderInBatchesNotAnArray",
+			args: args{
+				uid:       "jsonbatch",
+				client:    defaultClient,
+				batchSize: 2,
+				documents: `{"id": 1, "name": "Alice In Wonderland"}`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "TestIndexAddDocumentsJSONFromReaderInBatchesTruncated",
+			args: args{
+				uid:       "jsonbatch",
+				client:    defaultClient,
+				batchSize: 2,
+				documents: `[{"id": 1, "name": "Alice In Wonderland"}`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.args.client
+			i := c.Index(tt.args.uid)
+			t.Cleanup(cleanup(c))
+
+			gotResp, err := i.AddDocumentsJSONFromReaderInBatches(strings.NewReader(tt.args.documents), tt.args.batchSize)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantResp, gotResp)
+			testWaitForPendingBatchUpdate(t, i, gotResp)
+
+			var wantDocs []map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.args.documents), &wantDocs))
+			var documents []map[string]interface{}
+			err = i.GetDocuments(&DocumentsRequest{}, &documents)
+			require.NoError(t, err)
+			require.Equal(t, wantDocs, documents)
+		})
+	}
+}
+
 func TestIndex_DeleteAllDocuments(t *testing.T) {
 	type args struct {
 		UID    string
@@ -996,6 +1088,94 @@ func TestIndex_DeleteDocuments(t *testing.T) {
 	}
 }
 
+func TestIndex_DeleteDocumentsByFilter(t *testing.T) {
+	type args struct {
+		UID    string
+		client *Client
+		filter interface{}
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantDocuments int64
+	}{
+		{
+			name: "TestIndexDeleteDocumentsByFilterString",
+			args: args{
+				UID:    "indexUID",
+				client: defaultClient,
+				filter: "tag = Novel OR year < 1800",
+			},
+			wantDocuments: 11,
+		},
+		{
+			name: "TestIndexDeleteDocumentsByFilterArrayWithCustomClient",
+			args: args{
+				UID:    "indexUID",
+				client: customClient,
+				filter: []interface{}{[]string{"tag = Tale", "tag = Tragedy"}, "year > 1900"},
+			},
+			wantDocuments: 17,
+		},
+		{
+			name: "TestIndexDeleteDocumentsByFilterNoMatch",
+			args: args{
+				UID:    "indexUID",
+				client: defaultClient,
+				filter: "tag = Poetry",
+			},
+			wantDocuments: 20,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.args.client
+			i := c.Index(tt.args.UID)
+			t.Cleanup(cleanup(c))
+			SetUpIndexForFaceting()
+
+			update, err := i.UpdateFilterableAttributes(&[]string{"tag", "year"})
+			require.NoError(t, err)
+			testWaitForPendingUpdate(t, i, update)
+
+			gotResp, err := i.DeleteDocumentsByFilter(context.Background(), tt.args.filter)
+			require.NoError(t, err)
+			testWaitForPendingBatchUpdate(t, i, gotResp)
+
+			stats, err := i.GetStats()
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDocuments, stats.NumberOfDocuments)
+		})
+	}
+}
+
+func TestIndex_DeleteDocumentsByFilterLargeIDs(t *testing.T) {
+	c := defaultClient
+	i := c.Index("largeids")
+	t.Cleanup(cleanup(c))
+
+	// Above 2^53, rounded to their neighbours as float64
+	update, err := i.AddDocuments([]map[string]interface{}{
+		{"id": int64(9007199254740993), "tag": "old"},
+		{"id": int64(9007199254740995), "tag": "old"},
+		{"id": int64(9007199254740994), "tag": "new"},
+	}, "id")
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+	update, err = i.UpdateFilterableAttributes(&[]string{"tag"})
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+
+	gotResp, err := i.DeleteDocumentsByFilter(context.Background(), "tag = old")
+	require.NoError(t, err)
+	testWaitForPendingBatchUpdate(t, i, gotResp)
+
+	var documents []map[string]interface{}
+	require.NoError(t, i.GetDocuments(&DocumentsRequest{}, &documents))
+	require.Len(t, documents, 1)
+	require.Equal(t, "new", documents[0]["tag"])
+}
+
 func TestIndex_GetDocument(t *testing.T) {
 	type args struct {
 		UID         string
@@ -1389,3 +1569,154 @@ func TestIndex_UpdateDocumentsInBatches(t *testing.T) {
 		})
 	}
 }
+
+var testCsvPriceUpdates = []byte(`id,price:number
+1,9.5
+3,12
+5,7.25
+`)
+
+var testNdjsonPriceUpdates = []byte(`{"id": 1, "price": 9.5}
+{"id": 3, "price": 12}
+{"id": 5, "price": 7.25}
+`)
+
+func testPriceUpdatedDocuments() []map[string]interface{} {
+	return []map[string]interface{}{
+		{"id": float64(1), "name": "Alice In Wonderland", "price": 9.5},
+		{"id": float64(2), "name": "Pride and Prejudice"},
+		{"id": float64(3), "name": "Le Petit Prince", "price": float64(12)},
+		{"id": float64(4), "name": "The Great Gatsby"},
+		{"id": float64(5), "name": "Don Quixote", "price": 7.25},
+	}
+}
+
+func TestIndex_UpdateDocumentsCsv(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(i *Index, documents []byte) ([]AsyncUpdateID, error)
+	}{
+		{
+			name: "TestIndexUpdateDocumentsCsv",
+			update: func(i *Index, documents []byte) ([]AsyncUpdateID, error) {
+				resp, err := i.UpdateDocumentsCsv(documents)
+				if err != nil {
+					return nil, err
+				}
+				return []AsyncUpdateID{*resp}, nil
+			},
+		},
+		{
+			name: "TestIndexUpdateDocumentsCsvFromReader",
+			update: func(i *Index, documents []byte) ([]AsyncUpdateID, error) {
+				resp, err := i.UpdateDocumentsCsvFromReader(bytes.NewReader(documents))
+				if err != nil {
+					return nil, err
+				}
+				return []AsyncUpdateID{*resp}, nil
+			},
+		},
+		{
+			name: "TestIndexUpdateDocumentsCsvInBatches",
+			update: func(i *Index, documents []byte) ([]AsyncUpdateID, error) {
+				return i.UpdateDocumentsCsvInBatches(documents, 2)
+			},
+		},
+		{
+			name: "TestIndexUpdateDocumentsCsvFromReaderInBatches",
+			update: func(i *Index, documents []byte) ([]AsyncUpdateID, error) {
+				return i.UpdateDocumentsCsvFromReaderInBatches(bytes.NewReader(documents), 2)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := defaultClient
+			i := c.Index("csvupdate")
+			t.Cleanup(cleanup(c))
+
+			addResp, err := i.AddDocumentsNdjson(testNdjsonDocuments)
+			require.NoError(t, err)
+			testWaitForPendingUpdate(t, i, addResp)
+
+			gotResp, err := tt.update(i, testCsvPriceUpdates)
+			require.NoError(t, err)
+			testWaitForPendingBatchUpdate(t, i, gotResp)
+
+			var documents []map[string]interface{}
+			err = i.GetDocuments(&DocumentsRequest{}, &documents)
+			require.NoError(t, err)
+			require.Equal(t, testPriceUpdatedDocuments(), documents)
+		})
+	}
+}
+
+func TestIndex_UpdateDocumentsNdjson(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(i *Index, documents []byte) ([]AsyncUpdateID, error)
+	}{
+		{
+			name: "TestIndexUpdateDocumentsNdjson",
+			update: func(i *Index, documents []byte) ([]AsyncUpdateID, error) {
+				resp, err := i.UpdateDocumentsNdjson(documents)
+				if err != nil {
+					return nil, err
+				}
+				return []AsyncUpdateID{*resp}, nil
+			},
+		},
+		{
+			name: "TestIndexUpdateDocumentsNdjsonFromReader",
+			update: func(i *Index, documents []byte) ([]AsyncUpdateID, error) {
+				resp, err := i.UpdateDocumentsNdjsonFromReader(bytes.NewReader(documents))
+				if err != nil {
+					return nil, err
+				}
+				return []AsyncUpdateID{*resp}, nil
+			},
+		},
+		{
+			name: "TestIndexUpdateDocumentsNdjsonInBatches",
+			update: func(i *Index, documents []byte) ([]AsyncUpdateID, error) {
+				return i.UpdateDocumentsNdjsonInBatches(documents, 2)
+			},
+		},
+		{
+			name: "TestIndexUpdateDocumentsNdjsonFromReaderInBatches",
+			update: func(i *Index, documents []byte) ([]AsyncUpdateID, error) {
+				return i.UpdateDocumentsNdjsonFromReaderInBatches(bytes.NewReader(documents), 2)
+			},
+		},
+		{
+			name: "TestIndexUpdateDocumentsJSONFromReaderInBatches",
+			update: func(i *Index, documents []byte) ([]AsyncUpdateID, error) {
+				return i.UpdateDocumentsJSONFromReaderInBatches(strings.NewReader(`[
+					{"id": 1, "price": 9.5},
+					{"id": 3, "price": 12},
+					{"id": 5, "price": 7.25}
+				]`), 2)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := defaultClient
+			i := c.Index("ndjsonupdate")
+			t.Cleanup(cleanup(c))
+
+			addResp, err := i.AddDocumentsNdjson(testNdjsonDocuments)
+			require.NoError(t, err)
+			testWaitForPendingUpdate(t, i, addResp)
+
+			gotResp, err := tt.update(i, testNdjsonPriceUpdates)
+			require.NoError(t, err)
+			testWaitForPendingBatchUpdate(t, i, gotResp)
+
+			var documents []map[string]interface{}
+			err = i.GetDocuments(&DocumentsRequest{}, &documents)
+			require.NoError(t, err)
+			require.Equal(t, testPriceUpdatedDocuments(), documents)
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package meilisearch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// validDocumentID matches the document identifiers accepted by Meilisearch
+var validDocumentID = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// InvalidDocument describes a document rejected by the client-side validation
+type InvalidDocument struct {
+	// Position of the document in the batch, starting at 0
+	Position int
+	// ID is the raw primary key value, empty if missing
+	ID string
+	// Reason explains why the document is invalid
+	Reason string
+}
+
+// DocumentValidationError is returned when documents would be rejected by
+// Meilisearch, before anything is sent.
+type DocumentValidationError struct {
+	// PrimaryKey used to validate the documents, empty if it could not be determined
+	PrimaryKey string
+	// Reason is set when the whole batch is invalid
+	Reason string
+	// Documents lists the offending documents
+	Documents []InvalidDocument
+}
+
+// Error return a well human formatted message.
+func (e *DocumentValidationError) Error() string {
+	if e.Reason != "" {
+		return "invalid documents: " + e.Reason
+	}
+	messages := make([]string, len(e.Documents))
+	for j, document := range e.Documents {
+		if document.ID != "" {
+			messages[j] = fmt.Sprintf("document %d (%s %q): %s", document.Position, e.PrimaryKey, document.ID, document.Reason)
+		} else {
+			messages[j] = fmt.Sprintf("document %d: %s", document.Position, document.Reason)
+		}
+	}
+	return fmt.Sprintf("%d invalid documents: %s", len(e.Documents), strings.Join(messages, "; "))
+}
+
+// ValidateDocuments checks that every document has a valid and unique
+// primary key. When primaryKey is empty it is inferred from the first
+// document as Meilisearch does: the only attribute containing "id".
+func ValidateDocuments(documentsPtr interface{}, primaryKey string) error {
+	return validateDocuments(DefaultJSONCodec(), documentsPtr, primaryKey)
+}
+
+func validateDocuments(codec JSONCodec, documentsPtr interface{}, primaryKey string) error {
+	var (
+		documents []map[string]interface{}
+		err       error
+	)
+	if data, ok := documentsPtr.([]byte); ok {
+		err = errors.Wrap(unmarshalWithNumbers(codec, data, &documents), "documents must be an array of objects")
+	} else {
+		documents, err = normalizeDocuments(codec, documentsPtr)
+	}
+	if err != nil {
+		return err
+	}
+	return validateDocumentMaps(documents, primaryKey)
+}
+
+// ValidateDocumentsNdjson is ValidateDocuments for NDJSON documents
+func ValidateDocumentsNdjson(documents []byte, primaryKey string) error {
+	return validateDocumentsNdjson(DefaultJSONCodec(), documents, primaryKey)
+}
+
+func validateDocumentsNdjson(codec JSONCodec, documents []byte, primaryKey string) error {
+	var maps []map[string]interface{}
+	scanner := bufio.NewScanner(bytes.NewReader(documents))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		document, err := decodeDocument(codec, line)
+		if err != nil {
+			return err
+		}
+		maps = append(maps, document)
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "could not read NDJSON")
+	}
+	return validateDocumentMaps(maps, primaryKey)
+}
+
+// ValidateDocumentsCsv is ValidateDocuments for CSV documents with a header
+// row, type annotations of the header ("price:number") are ignored.
+func ValidateDocumentsCsv(documents []byte, primaryKey string) error {
+	var (
+		header []string
+		maps   []map[string]interface{}
+	)
+	r := csv.NewReader(bytes.NewReader(documents))
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not read CSV record")
+		}
+		if header == nil {
+			header = make([]string, len(record))
+			for j, field := range record {
+				header[j] = strings.SplitN(field, ":", 2)[0]
+			}
+			continue
+		}
+		document := make(map[string]interface{}, len(record))
+		for j, value := range record {
+			if j < len(header) && value != "" {
+				document[header[j]] = value
+			}
+		}
+		maps = append(maps, document)
+	}
+	if primaryKey == "" && header != nil {
+		// Infer from the header as records omit their empty fields
+		inferred, err := inferPrimaryKey(header)
+		if err != nil {
+			return err
+		}
+		primaryKey = inferred
+	}
+	return validateDocumentMaps(maps, primaryKey)
+}
+
+func validateDocumentMaps(documents []map[string]interface{}, primaryKey string) error {
+	if len(documents) == 0 {
+		return nil
+	}
+	if primaryKey == "" {
+		fields := make([]string, 0, len(documents[0]))
+		for field := range documents[0] {
+			fields = append(fields, field)
+		}
+		inferred, err := inferPrimaryKey(fields)
+		if err != nil {
+			return err
+		}
+		primaryKey = inferred
+	}
+
+	validationErr := &DocumentValidationError{PrimaryKey: primaryKey}
+	seen := make(map[string]int, len(documents))
+	for j, document := range documents {
+		value, ok := document[primaryKey]
+		if !ok || value == nil {
+			validationErr.Documents = append(validationErr.Documents, InvalidDocument{
+				Position: j,
+				Reason:   "missing primary key",
+			})
+			continue
+		}
+
+		var id string
+		switch v := value.(type) {
+		case string:
+			id = v
+			if !validDocumentID.MatchString(v) {
+				validationErr.Documents = append(validationErr.Documents, InvalidDocument{
+					Position: j,
+					ID:       id,
+					Reason:   "document id must only contain alphanumeric characters, '-' and '_'",
+				})
+				continue
+			}
+		case json.Number, float64:
+			number, _ := documentNumber(v)
+			id = number.String()
+			if _, err := number.Int64(); err != nil {
+				validationErr.Documents = append(validationErr.Documents, InvalidDocument{
+					Position: j,
+					ID:       id,
+					Reason:   "document id must be an integer or a string",
+				})
+				continue
+			}
+		default:
+			validationErr.Documents = append(validationErr.Documents, InvalidDocument{
+				Position: j,
+				ID:       fmt.Sprintf("%v", v),
+				Reason:   "document id must be an integer or a string",
+			})
+			continue
+		}
+
+		if first, ok := seen[id]; ok {
+			validationErr.Documents = append(validationErr.Documents, InvalidDocument{
+				Position: j,
+				ID:       id,
+				Reason:   fmt.Sprintf("duplicate of document %d", first),
+			})
+			continue
+		}
+		seen[id] = j
+	}
+
+	if len(validationErr.Documents) != 0 {
+		return validationErr
+	}
+	return nil
+}
+
+// inferPrimaryKey returns the only field containing "id", case insensitive.
+func inferPrimaryKey(fields []string) (string, error) {
+	var candidates []string
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), "id") {
+			candidates = append(candidates, field)
+		}
+	}
+	sort.Strings(candidates)
+	switch len(candidates) {
+	case 0:
+		return "", &DocumentValidationError{Reason: "no primary key found, no attribute contains \"id\""}
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", &DocumentValidationError{
+			Reason: fmt.Sprintf("the primary key is ambiguous, candidates are %s", strings.Join(candidates, ", ")),
+		}
+	}
+}
+
+// validationPrimaryKey returns the primary key documents added to the index
+// are validated against: primaryKey when given, else the primary key of the
+// index. It is empty for an index without primary key or not yet created, the
+// primary key is then inferred from the documents.
+func (i Index) validationPrimaryKey(primaryKey ...string) (string, error) {
+	if primaryKey != nil {
+		return primaryKey[0], nil
+	}
+	if i.PrimaryKey != "" {
+		return i.PrimaryKey, nil
+	}
+	index, err := i.FetchInfo()
+	if err != nil {
+		if apiErr, ok := err.(*Error); ok && apiErr.MeilisearchApiError.Code == "index_not_found" {
+			return "", nil
+		}
+		return "", err
+	}
+	return index.PrimaryKey, nil
+}
+
+// validateDocuments checks documents before they are sent by addDocuments when
+// ClientConfig.ValidateDocuments is enabled.
+func (i Index) validateDocuments(documents interface{}, contentType string, primaryKey string) error {
+	switch contentType {
+	case contentTypeCSV:
+		data, _ := documents.([]byte)
+		return ValidateDocumentsCsv(data, primaryKey)
+	case contentTypeNDJSON:
+		data, _ := documents.([]byte)
+		return validateDocumentsNdjson(i.client.jsonCodec(), data, primaryKey)
+	default:
+		return validateDocuments(i.client.jsonCodec(), documents, primaryKey)
+	}
+}
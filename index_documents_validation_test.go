@@ -0,0 +1,193 @@
+package meilisearch
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+func TestValidateDocuments(t *testing.T) {
+	tests := []struct {
+		name         string
+		documentsPtr interface{}
+		primaryKey   string
+		wantErr      *DocumentValidationError
+	}{
+		{
+			name: "TestValidateDocumentsValid",
+			documentsPtr: []docTestBooks{
+				{BookID: 123, Title: "Pride and Prejudice"},
+				{BookID: 456, Title: "Le Petit Prince"},
+			},
+			primaryKey: "book_id",
+		},
+		{
+			name: "TestValidateDocumentsInferPrimaryKey",
+			documentsPtr: []docTest{
+				{ID: "123", Name: "Pride and Prejudice"},
+				{ID: "456", Name: "Le Petit Prince"},
+			},
+		},
+		{
+			name:         "TestValidateDocumentsRawJSON",
+			documentsPtr: []byte(`[{"id": "a-1"}, {"id": 2}]`),
+			primaryKey:   "id",
+		},
+		{
+			name: "TestValidateDocumentsInvalidDocuments",
+			documentsPtr: []map[string]interface{}{
+				{"id": "123"},
+				{"id": "the hobbit"},
+				{"title": "Le Petit Prince"},
+				{"id": "123"},
+				{"id": 1.5},
+				{"id": true},
+			},
+			primaryKey: "id",
+			wantErr: &DocumentValidationError{
+				PrimaryKey: "id",
+				Documents: []InvalidDocument{
+					{Position: 1, ID: "the hobbit", Reason: "document id must only contain alphanumeric characters, '-' and '_'"},
+					{Position: 2, Reason: "missing primary key"},
+					{Position: 3, ID: "123", Reason: "duplicate of document 0"},
+					{Position: 4, ID: "1.5", Reason: "document id must be an integer or a string"},
+					{Position: 5, ID: "true", Reason: "document id must be an integer or a string"},
+				},
+			},
+		},
+		{
+			name: "TestValidateDocumentsAmbiguousPrimaryKey",
+			documentsPtr: []map[string]interface{}{
+				{"id": 1, "book_id": 123},
+			},
+			wantErr: &DocumentValidationError{
+				Reason: "the primary key is ambiguous, candidates are book_id, id",
+			},
+		},
+		{
+			name: "TestValidateDocumentsNoPrimaryKey",
+			documentsPtr: []map[string]interface{}{
+				{"title": "Le Petit Prince"},
+			},
+			wantErr: &DocumentValidationError{
+				Reason: "no primary key found, no attribute contains \"id\"",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDocuments(tt.documentsPtr, tt.primaryKey)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestValidateDocumentsNdjson(t *testing.T) {
+	err := ValidateDocumentsNdjson([]byte(`{"id": 1, "title": "Alice In Wonderland"}
+{"id": 2, "title": "Le Petit Prince"}
+
+{"id": 1, "title": "Pride and Prejudice"}
+`), "")
+	require.Equal(t, &DocumentValidationError{
+		PrimaryKey: "id",
+		Documents: []InvalidDocument{
+			{Position: 2, ID: "1", Reason: "duplicate of document 0"},
+		},
+	}, err)
+}
+
+func TestValidateDocumentsCsv(t *testing.T) {
+	err := ValidateDocumentsCsv([]byte("id:number,title\n1,Alice In Wonderland\n,Le Petit Prince\n3,\"Pride and Prejudice\"\n"), "")
+	require.Equal(t, &DocumentValidationError{
+		PrimaryKey: "id",
+		Documents: []InvalidDocument{
+			{Position: 1, Reason: "missing primary key"},
+		},
+	}, err)
+
+	err = ValidateDocumentsCsv([]byte("id,title\n1,Alice In Wonderland\n"), "")
+	require.NoError(t, err)
+}
+
+func TestIndex_AddDocumentsWithValidation(t *testing.T) {
+	c := NewClient(ClientConfig{
+		Host:              "http://localhost:7700",
+		APIKey:            masterKey,
+		ValidateDocuments: true,
+	})
+	i := c.Index("TestIndexAddDocumentsWithValidation")
+	t.Cleanup(cleanup(c))
+
+	_, err := i.AddDocuments([]map[string]interface{}{
+		{"id": "123", "title": "Pride and Prejudice"},
+		{"id": "123", "title": "Le Petit Prince"},
+	})
+	require.IsType(t, &DocumentValidationError{}, err)
+
+	_, err = i.AddDocumentsNdjson([]byte(`{"id": "1 2", "title": "Pride and Prejudice"}`), "id")
+	require.IsType(t, &DocumentValidationError{}, err)
+
+	_, err = i.UpdateDocuments([]map[string]interface{}{
+		{"title": "Pride and Prejudice"},
+	}, "id")
+	require.IsType(t, &DocumentValidationError{}, err)
+
+	gotResp, err := i.AddDocumentsCsv([]byte("id,title\n123,Pride and Prejudice\n456,Le Petit Prince\n"))
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, gotResp)
+
+	// The primary key of the index is used once it exists
+	_, err = i.AddDocuments([]map[string]interface{}{
+		{"book_id": "1", "title": "Alice In Wonderland"},
+	})
+	require.IsType(t, &DocumentValidationError{}, err)
+}
+
+func TestIndex_AddDocumentsInBatchesFetchesPrimaryKeyOnce(t *testing.T) {
+	ln := fasthttputil.NewInmemoryListener()
+	var fetches, additions int32
+	server := &fasthttp.Server{Handler: func(ctx *fasthttp.RequestCtx) {
+		switch {
+		case ctx.IsGet() && string(ctx.Path()) == "/indexes/books":
+			atomic.AddInt32(&fetches, 1)
+			ctx.SetBodyString(`{"uid": "books", "primaryKey": "book_id"}`)
+		case ctx.IsPost() && string(ctx.Path()) == "/indexes/books/documents":
+			require.Empty(t, ctx.QueryArgs().Peek("primaryKey"))
+			ctx.SetStatusCode(http.StatusAccepted)
+			ctx.SetBodyString(fmt.Sprintf(`{"updateId": %d}`, atomic.AddInt32(&additions, 1)))
+		default:
+			ctx.SetStatusCode(http.StatusNotFound)
+		}
+	}}
+	go func() { _ = server.Serve(ln) }()
+	t.Cleanup(func() { _ = ln.Close() })
+
+	c := NewFastHTTPCustomClient(ClientConfig{
+		Host:              "http://books.local",
+		ValidateDocuments: true,
+	}, &fasthttp.Client{Dial: func(string) (net.Conn, error) { return ln.Dial() }})
+	i := c.Index("books")
+
+	gotResp, err := i.AddDocumentsInBatches([]map[string]interface{}{
+		{"book_id": 1, "title": "Pride and Prejudice"},
+		{"book_id": 2, "title": "Le Petit Prince"},
+		{"book_id": 3, "title": "Alice In Wonderland"},
+	}, 1)
+	require.NoError(t, err)
+	require.Len(t, gotResp, 3)
+	require.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	_, err = i.AddDocumentsNdjsonInBatches([]byte("{\"book_id\": 4}\n{\"id\": 5}\n"), 1)
+	require.IsType(t, &DocumentValidationError{}, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+}
@@ -0,0 +1,182 @@
+package meilisearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// ExportFormat is the output format used by ExportDocuments.
+type ExportFormat string
+
+const (
+	// ExportFormatNDJSON writes one JSON document per line
+	ExportFormatNDJSON ExportFormat = "ndjson"
+	// ExportFormatCSV writes an RFC 4180 CSV file with a header row
+	ExportFormatCSV ExportFormat = "csv"
+)
+
+// exportPageSize is the number of documents fetched per GetDocuments call
+// while exporting, it bounds the memory used by an export.
+const exportPageSize int64 = 1000
+
+// ExportDocuments streams every document of the index to w in the given format.
+// Documents are fetched page by page so only one page is held in memory at a
+// time. As pages are fetched by offset, documents added or deleted during the
+// export may be skipped or written twice.
+//
+// The CSV header is the primary key followed by the other fields of
+// StatsIndex.FieldDistribution in alphabetical order. Nested values (arrays and
+// objects) are written as JSON and null values as empty cells.
+func (i Index) ExportDocuments(ctx context.Context, w io.Writer, format ExportFormat) error {
+	switch format {
+	case ExportFormatNDJSON:
+		return i.exportDocumentsNdjson(ctx, w)
+	case ExportFormatCSV:
+		return i.exportDocumentsCsv(ctx, w)
+	default:
+		return fmt.Errorf("unsupported export format: %q", format)
+	}
+}
+
+func (i Index) exportDocumentsNdjson(ctx context.Context, w io.Writer) error {
+	return i.forEachDocumentsPage(ctx, exportPageSize, nil, func(documents []json.RawMessage) error {
+		b := new(bytes.Buffer)
+		for _, document := range documents {
+			if err := json.Compact(b, document); err != nil {
+				return errors.Wrap(err, "could not write NDJSON line")
+			}
+			b.WriteByte('\n')
+		}
+		if _, err := w.Write(b.Bytes()); err != nil {
+			return errors.Wrap(err, "could not write NDJSON line")
+		}
+		return nil
+	})
+}
+
+func (i Index) exportDocumentsCsv(ctx context.Context, w io.Writer) error {
+	header, err := i.exportCsvHeader()
+	if err != nil {
+		return err
+	}
+	columns := make(map[string]int, len(header))
+	for j, field := range header {
+		columns[field] = j
+	}
+
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true // Keep output RFC 4180 compliant
+	if err := cw.Write(header); err != nil {
+		return errors.Wrap(err, "could not write CSV header")
+	}
+
+	err = i.forEachDocumentsPage(ctx, exportPageSize, nil, func(documents []json.RawMessage) error {
+		for _, raw := range documents {
+			document, err := decodeDocument(i.client.jsonCodec(), raw)
+			if err != nil {
+				return err
+			}
+
+			record := make([]string, len(header))
+			for field, value := range document {
+				j, ok := columns[field]
+				if !ok {
+					return fmt.Errorf("field %q is not part of the CSV header, the index changed during the export", field)
+				}
+				if record[j], err = csvCellValue(i.client.jsonCodec(), value); err != nil {
+					return err
+				}
+			}
+			if err := cw.Write(record); err != nil {
+				return errors.Wrap(err, "could not write CSV record")
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportCsvHeader returns the primary key followed by every other field known
+// by the index in alphabetical order.
+func (i Index) exportCsvHeader() ([]string, error) {
+	stats, err := i.GetStats()
+	if err != nil {
+		return nil, err
+	}
+	primaryKey, err := i.FetchPrimaryKey()
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]string, 0, len(stats.FieldDistribution))
+	for field := range stats.FieldDistribution {
+		if field != *primaryKey {
+			header = append(header, field)
+		}
+	}
+	sort.Strings(header)
+	if *primaryKey != "" {
+		header = append([]string{*primaryKey}, header...)
+	}
+	return header, nil
+}
+
+func csvCellValue(codec JSONCodec, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		data, err := codec.Marshal(v)
+		if err != nil {
+			return "", errors.Wrap(err, "could not encode CSV cell")
+		}
+		return string(data), nil
+	}
+}
+
+// forEachDocumentsPage fetches every document of the index, pageSize documents
+// at a time, and calls fn with the raw JSON of each page. Only the
+// attributesToRetrieve are fetched when given.
+func (i Index) forEachDocumentsPage(ctx context.Context, pageSize int64, attributesToRetrieve []string, fn func(documents []json.RawMessage) error) error {
+	for offset := int64(0); ; offset += pageSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var documents []json.RawMessage
+		if err := i.GetDocuments(&DocumentsRequest{
+			Offset:               offset,
+			Limit:                pageSize,
+			AttributesToRetrieve: attributesToRetrieve,
+		}, &documents); err != nil {
+			return err
+		}
+		if len(documents) == 0 {
+			return nil
+		}
+		if err := fn(documents); err != nil {
+			return err
+		}
+		if int64(len(documents)) < pageSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package meilisearch
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_ExportDocuments(t *testing.T) {
+	type args struct {
+		UID    string
+		client *Client
+		format ExportFormat
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "TestIndexExportDocumentsNdjson",
+			args: args{
+				UID:    "indexUID",
+				client: defaultClient,
+				format: ExportFormatNDJSON,
+			},
+			want: `{"book_id":123,"title":"Pride and Prejudice"}` + "\n" +
+				`{"book_id":456,"title":"Le Petit Prince"}` + "\n" +
+				`{"book_id":1,"title":"Alice In Wonderland"}` + "\n" +
+				`{"book_id":1344,"title":"The Hobbit"}` + "\n" +
+				`{"book_id":4,"title":"Harry Potter and the Half-Blood Prince"}` + "\n" +
+				`{"book_id":42,"title":"The Hitchhiker's Guide to the Galaxy"}` + "\n",
+		},
+		{
+			name: "TestIndexExportDocumentsCsvWithCustomClient",
+			args: args{
+				UID:    "indexUID",
+				client: customClient,
+				format: ExportFormatCSV,
+			},
+			want: "book_id,title\r\n" +
+				"123,Pride and Prejudice\r\n" +
+				"456,Le Petit Prince\r\n" +
+				"1,Alice In Wonderland\r\n" +
+				"1344,The Hobbit\r\n" +
+				"4,Harry Potter and the Half-Blood Prince\r\n" +
+				"42,The Hitchhiker's Guide to the Galaxy\r\n",
+		},
+		{
+			name: "TestIndexExportDocumentsUnknownFormat",
+			args: args{
+				UID:    "indexUID",
+				client: defaultClient,
+				format: ExportFormat("xml"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.args.client
+			i := c.Index(tt.args.UID)
+			t.Cleanup(cleanup(c))
+			SetUpBasicIndex()
+
+			b := new(bytes.Buffer)
+			err := i.ExportDocuments(context.Background(), b, tt.args.format)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, b.String())
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package meilisearch
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// ImportFormat is the format of the documents of a file imported by ImportFile
+type ImportFormat string
+
+const (
+	// ImportFormatJSON is a JSON array of documents
+	ImportFormatJSON ImportFormat = "json"
+	// ImportFormatNDJSON is one JSON document per line
+	ImportFormatNDJSON ImportFormat = "ndjson"
+	// ImportFormatCSV is an RFC 4180 CSV file with a header row
+	ImportFormatCSV ImportFormat = "csv"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	utf8BOM   = []byte{0xef, 0xbb, 0xbf}
+)
+
+// ImportFileOptions configure ImportFile
+type ImportFileOptions struct {
+
+	// Format of the documents, detected from the file when empty
+	Format ImportFormat
+
+	// BatchSize is the number of documents sent at a time. Default to 1000.
+	BatchSize int
+
+	// PrimaryKey is optional
+	PrimaryKey string
+}
+
+// ImportFile adds the documents of a JSON, NDJSON or CSV file in batches.
+//
+// Gzip and zstd compressed files are decompressed on the fly, the compression
+// is detected from the magic bytes of the file. Unless opts.Format is set,
+// the format is detected from the extension once the compression extension is
+// removed (.json, .ndjson, .jsonl, .csv), or else from the first character of
+// the content: '[' for JSON, '{' for NDJSON and CSV otherwise. A leading UTF-8
+// byte order mark is ignored.
+func (i Index) ImportFile(ctx context.Context, path string, opts *ImportFileOptions) (resp []AsyncUpdateID, err error) {
+	if opts == nil {
+		opts = &ImportFileOptions{}
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	var primaryKey []string
+	if opts.PrimaryKey != "" {
+		primaryKey = []string{opts.PrimaryKey}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open documents file")
+	}
+	defer f.Close()
+
+	documents, err := decompressedReader(bufio.NewReader(f))
+	if err != nil {
+		return nil, err
+	}
+	defer documents.Close()
+
+	r := bufio.NewReader(&contextReader{ctx: ctx, r: documents})
+	skipBOM(r)
+	format := opts.Format
+	if format == "" {
+		if format, err = detectImportFormat(path, r); err != nil {
+			return nil, err
+		}
+	}
+
+	switch format {
+	case ImportFormatJSON:
+		return i.AddDocumentsJSONFromReaderInBatches(r, batchSize, primaryKey...)
+	case ImportFormatNDJSON:
+		return i.AddDocumentsNdjsonFromReaderInBatches(r, batchSize, primaryKey...)
+	case ImportFormatCSV:
+		return i.AddDocumentsCsvFromReaderInBatches(r, batchSize, primaryKey...)
+	default:
+		return nil, fmt.Errorf("unsupported import format: %q", format)
+	}
+}
+
+// decompressedReader returns the content of r, decompressed according to its magic bytes
+func decompressedReader(r *bufio.Reader) (io.ReadCloser, error) {
+	magic, _ := r.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read gzip documents")
+		}
+		return gr, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read zstd documents")
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return ioutil.NopCloser(r), nil
+	}
+}
+
+func detectImportFormat(path string, r *bufio.Reader) (ImportFormat, error) {
+	name := strings.ToLower(filepath.Base(path))
+	for _, ext := range []string{".gz", ".gzip", ".zst", ".zstd"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+	switch filepath.Ext(name) {
+	case ".json":
+		return ImportFormatJSON, nil
+	case ".ndjson", ".jsonl":
+		return ImportFormatNDJSON, nil
+	case ".csv":
+		return ImportFormatCSV, nil
+	}
+
+	// Look at the first non blank character of the content, the blanks are
+	// consumed as they are insignificant in every format
+	for {
+		c, err := r.ReadByte()
+		if err == io.EOF {
+			return "", fmt.Errorf("could not detect the format of %s: the file is empty", path)
+		}
+		if err != nil {
+			return "", errors.Wrap(err, "could not detect the format of documents")
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		if err := r.UnreadByte(); err != nil {
+			return "", errors.Wrap(err, "could not detect the format of documents")
+		}
+		switch c {
+		case '[':
+			return ImportFormatJSON, nil
+		case '{':
+			return ImportFormatNDJSON, nil
+		default:
+			return ImportFormatCSV, nil
+		}
+	}
+}
+
+// skipBOM discards the UTF-8 byte order mark starting r, if any
+func skipBOM(r *bufio.Reader) {
+	if bom, _ := r.Peek(len(utf8BOM)); bytes.Equal(bom, utf8BOM) {
+		_, _ = r.Discard(len(utf8BOM))
+	}
+}
+
+// contextReader stops reading once its context is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,163 @@
+package meilisearch
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+var testJSONDocuments = []byte(`[
+	{"id": 1, "name": "Alice In Wonderland"},
+	{"id": 2, "name": "Pride and Prejudice"},
+	{"id": 3, "name": "Le Petit Prince"},
+	{"id": 4, "name": "The Great Gatsby"},
+	{"id": 5, "name": "Don Quixote"}
+]`)
+
+func testGzip(t *testing.T, data []byte) []byte {
+	b := new(bytes.Buffer)
+	w := gzip.NewWriter(b)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return b.Bytes()
+}
+
+func testZstd(t *testing.T, data []byte) []byte {
+	b := new(bytes.Buffer)
+	w, err := zstd.NewWriter(b)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return b.Bytes()
+}
+
+func Test_detectImportFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    ImportFormat
+		wantErr bool
+	}{
+		{name: "TestDetectJSONExtension", path: "books.json", content: "", want: ImportFormatJSON},
+		{name: "TestDetectNdjsonCompressedExtension", path: "books.ndjson.gz", content: "", want: ImportFormatNDJSON},
+		{name: "TestDetectJsonlExtension", path: "books.JSONL.zst", content: "", want: ImportFormatNDJSON},
+		{name: "TestDetectCsvExtension", path: "/tmp/books.csv", content: "", want: ImportFormatCSV},
+		{name: "TestDetectJSONContent", path: "books", content: "\n  [{\"id\": 1}]", want: ImportFormatJSON},
+		{name: "TestDetectNdjsonContent", path: "books.txt", content: "{\"id\": 1}\n", want: ImportFormatNDJSON},
+		{name: "TestDetectCsvContent", path: "books.dat", content: "id,name\n1,Ulysses\n", want: ImportFormatCSV},
+		{name: "TestDetectEmptyContent", path: "books", content: " \n", wantErr: true},
+		{name: "TestDetectJSONContentWithBOM", path: "books", content: "\xef\xbb\xbf[{\"id\": 1}]", want: ImportFormatJSON},
+		{name: "TestDetectNdjsonContentWithBOM", path: "books", content: "\xef\xbb\xbf\n{\"id\": 1}\n", want: ImportFormatNDJSON},
+		{name: "TestDetectCsvContentWithBOM", path: "books", content: "\xef\xbb\xbfid,name\n", want: ImportFormatCSV},
+		{name: "TestDetectJSONContentAfterLongBlank", path: "books", content: strings.Repeat(" \n", 5000) + "[{\"id\": 1}]", want: ImportFormatJSON},
+		{name: "TestDetectEmptyContentWithBOM", path: "books", content: "\xef\xbb\xbf" + strings.Repeat(" ", 5000), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := bufio.NewReader(strings.NewReader(tt.content))
+			skipBOM(r)
+			got, err := detectImportFormat(tt.path, r)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+
+			// The documents are still readable once the format is detected
+			rest, err := ioutil.ReadAll(r)
+			require.NoError(t, err)
+			require.True(t, strings.HasSuffix(tt.content, string(rest)))
+			require.False(t, bytes.HasPrefix(rest, utf8BOM))
+		})
+	}
+}
+
+func TestIndex_ImportFile(t *testing.T) {
+	type args struct {
+		client  *Client
+		file    string
+		content []byte
+		opts    *ImportFileOptions
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "TestIndexImportFileJSON",
+			args: args{
+				client:  defaultClient,
+				file:    "books.json",
+				content: testJSONDocuments,
+				opts:    &ImportFileOptions{BatchSize: 2},
+			},
+		},
+		{
+			name: "TestIndexImportFileNdjsonGzip",
+			args: args{
+				client:  defaultClient,
+				file:    "books.ndjson.gz",
+				content: testGzip(t, testNdjsonDocuments),
+				opts:    nil,
+			},
+		},
+		{
+			name: "TestIndexImportFileCsvZstdWithCustomClient",
+			args: args{
+				client:  customClient,
+				file:    "books.csv.zst",
+				content: testZstd(t, testCsvDocuments),
+				opts:    &ImportFileOptions{BatchSize: 3, PrimaryKey: "id"},
+			},
+		},
+		{
+			name: "TestIndexImportFileSniffedGzipWithoutExtension",
+			args: args{
+				client:  defaultClient,
+				file:    "books",
+				content: testGzip(t, testJSONDocuments),
+				opts:    nil,
+			},
+		},
+		{
+			name: "TestIndexImportFileForcedFormat",
+			args: args{
+				client:  defaultClient,
+				file:    "books.txt",
+				content: testNdjsonDocuments,
+				opts:    &ImportFileOptions{Format: ImportFormatNDJSON},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.args.client
+			i := c.Index("importfile")
+			t.Cleanup(cleanup(c))
+
+			path := filepath.Join(t.TempDir(), tt.args.file)
+			require.NoError(t, ioutil.WriteFile(path, tt.args.content, 0o644))
+
+			gotResp, err := i.ImportFile(context.Background(), path, tt.args.opts)
+			require.NoError(t, err)
+			testWaitForPendingBatchUpdate(t, i, gotResp)
+
+			var documents []map[string]interface{}
+			err = i.GetDocuments(&DocumentsRequest{}, &documents)
+			require.NoError(t, err)
+			require.Len(t, documents, 5)
+		})
+	}
+}
@@ -1,7 +1,14 @@
 package meilisearch
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"reflect"
+
+	"github.com/meilisearch/meilisearch-go/filter"
+	"github.com/pkg/errors"
 )
 
 // This constant contains the default values assigned by Meilisearch to the limit in search parameters
@@ -12,24 +19,142 @@ const (
 )
 
 func (i Index) Search(query string, request *SearchRequest) (*SearchResponse, error) {
-	resp := &SearchResponse{}
+	return i.searchWithParams(searchPostRequestParams(query, request), "Search")
+}
 
-	searchPostRequestParams := map[string]interface{}{}
+// searchWithParams runs a search, through the search cache of the client
+// when it has one
+func (i Index) searchWithParams(params map[string]interface{}, functionName string) (*SearchResponse, error) {
+	if i.client.searchCache == nil {
+		resp := &SearchResponse{}
+		if err := i.search(params, resp, functionName); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+	resp, hits, err := i.cachedSearch(params, functionName)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.decodeHits(hits, &resp.Hits); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// cachedSearch runs a search through the search cache of the client, the
+// hits are returned as raw JSON
+func (i Index) cachedSearch(params map[string]interface{}, functionName string) (*SearchResponse, []json.RawMessage, error) {
+	result, err := i.client.searchCache.search(i.UID, params, func() (*searchResult, error) {
+		var hits []json.RawMessage
+		resp := &searchIntoResponse{Hits: &hits}
+		if err := i.search(params, resp, functionName); err != nil {
+			return nil, err
+		}
+		return &searchResult{resp: resp.searchResponse(), hits: hits}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result.resp, result.hits, nil
+}
+
+// decodeHits decodes raw hits into hitsPtr, a pointer to a slice
+func (i Index) decodeHits(hits []json.RawMessage, hitsPtr interface{}) error {
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for j, hit := range hits {
+		if j > 0 {
+			b.WriteByte(',')
+		}
+		b.Write(hit)
+	}
+	b.WriteByte(']')
+	return errors.Wrap(i.client.jsonCodec().Unmarshal(b.Bytes(), hitsPtr), "could not decode hits")
+}
+
+// SearchInto is Search, except that the hits are decoded directly into
+// hitsPtr, a pointer to a slice of any type, instead of SearchResponse.Hits
+// which is left nil. The other fields of the response are set as with
+// Search.
+func (i Index) SearchInto(query string, request *SearchRequest, hitsPtr interface{}) (*SearchResponse, error) {
+	if hitsPtr == nil || reflect.TypeOf(hitsPtr).Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("SearchInto: hitsPtr must be a non-nil pointer, got %T", hitsPtr)
+	}
+	params := searchPostRequestParams(query, request)
+	if i.client.searchCache != nil {
+		resp, hits, err := i.cachedSearch(params, "SearchInto")
+		if err != nil {
+			return nil, err
+		}
+		if err := i.decodeHits(hits, hitsPtr); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+	resp := &searchIntoResponse{Hits: hitsPtr}
+	if err := i.search(params, resp, "SearchInto"); err != nil {
+		return nil, err
+	}
+	return resp.searchResponse(), nil
+}
+
+// SearchRaw is SearchInto with the hits kept as raw JSON
+func (i Index) SearchRaw(query string, request *SearchRequest) (*SearchResponse, []json.RawMessage, error) {
+	if i.client.searchCache != nil {
+		return i.cachedSearch(searchPostRequestParams(query, request), "SearchRaw")
+	}
+	var hits []json.RawMessage
+	resp, err := i.SearchInto(query, request, &hits)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, hits, nil
+}
 
-	if request.Limit == 0 {
-		request.Limit = DefaultLimit
+// searchIntoResponse is a SearchResponse whose hits are decoded into the
+// value pointed by Hits, as the JSON decoder uses the pointer held by a
+// non-nil interface.
+type searchIntoResponse struct {
+	Hits                  interface{}                 `json:"hits"`
+	NbHits                int64                       `json:"nbHits"`
+	Offset                int64                       `json:"offset"`
+	Limit                 int64                       `json:"limit"`
+	ExhaustiveNbHits      bool                        `json:"exhaustiveNbHits"`
+	ProcessingTimeMs      int64                       `json:"processingTimeMs"`
+	Query                 string                      `json:"query"`
+	FacetsDistribution    map[string]map[string]int64 `json:"facetsDistribution,omitempty"`
+	ExhaustiveFacetsCount bool                        `json:"exhaustiveFacetsCount,omitempty"`
+}
+
+func (r *searchIntoResponse) searchResponse() *SearchResponse {
+	return &SearchResponse{
+		NbHits:                r.NbHits,
+		Offset:                r.Offset,
+		Limit:                 r.Limit,
+		ExhaustiveNbHits:      r.ExhaustiveNbHits,
+		ProcessingTimeMs:      r.ProcessingTimeMs,
+		Query:                 r.Query,
+		FacetsDistribution:    r.FacetsDistribution,
+		ExhaustiveFacetsCount: r.ExhaustiveFacetsCount,
 	}
+}
+
+// searchPostRequestParams converts a SearchRequest to the body of a search,
+// request is left unchanged.
+func searchPostRequestParams(query string, request *SearchRequest) map[string]interface{} {
+	searchPostRequestParams := map[string]interface{}{}
 
 	if !request.PlaceholderSearch {
 		searchPostRequestParams["q"] = query
 	}
-	if request.Limit != DefaultLimit {
+	if request.Limit != 0 && request.Limit != DefaultLimit {
 		searchPostRequestParams["limit"] = request.Limit
 	}
 	if request.Matches {
 		searchPostRequestParams["matches"] = request.Matches
 	}
-	if request.Filter != nil {
+	if request.Filter != nil && !isEmptyExpression(request.Filter) {
 		searchPostRequestParams["filter"] = request.Filter
 	}
 	if request.Offset != 0 {
@@ -53,20 +178,26 @@ func (i Index) Search(query string, request *SearchRequest) (*SearchResponse, er
 	if len(request.Sort) != 0 {
 		searchPostRequestParams["sort"] = request.Sort
 	}
+	return searchPostRequestParams
+}
+
+// isEmptyExpression reports whether the filter is an empty filter.Expression,
+// like filter.And(), which is omitted instead of being sent as ""
+func isEmptyExpression(f interface{}) bool {
+	e, ok := f.(filter.Expression)
+	return ok && e.String() == ""
+}
 
+func (i Index) search(params map[string]interface{}, resp interface{}, functionName string) error {
 	req := internalRequest{
 		endpoint:            "/indexes/" + i.UID + "/search",
 		method:              http.MethodPost,
 		contentType:         contentTypeJSON,
-		withRequest:         searchPostRequestParams,
+		withRequest:         params,
 		withResponse:        resp,
 		acceptedStatusCodes: []int{http.StatusOK},
-		functionName:        "Search",
+		functionName:        functionName,
 	}
 
-	if err := i.client.executeRequest(req); err != nil {
-		return nil, err
-	}
-
-	return resp, nil
+	return i.client.executeRequest(req)
 }
@@ -0,0 +1,185 @@
+package meilisearch
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/meilisearch/meilisearch-go/filter"
+	"github.com/pkg/errors"
+)
+
+// SearchBuilder builds a search with chained calls:
+//
+//	search := NewSearch("prince").Limit(50).Offset(100).Filter(filter.Eq("tag", "Tale")).Sort("year:desc")
+//	resp, err := index.SearchWith(search)
+//
+// A SearchBuilder is immutable, every method returns a new builder so a
+// builder can be reused as the base of several searches. Unlike Search, a
+// limit of 0 set with Limit is sent as is.
+type SearchBuilder struct {
+	query       string
+	placeholder bool
+	request     SearchRequest
+	limit       *int64
+}
+
+// NewSearch starts a search for query
+func NewSearch(query string) SearchBuilder {
+	return SearchBuilder{query: query}
+}
+
+// NewPlaceholderSearch starts a search without query, matching every document
+func NewPlaceholderSearch() SearchBuilder {
+	return SearchBuilder{placeholder: true}
+}
+
+// Limit sets the maximum number of hits, 0 included
+func (b SearchBuilder) Limit(limit int64) SearchBuilder {
+	b.limit = &limit
+	return b
+}
+
+// Offset sets the number of hits to skip
+func (b SearchBuilder) Offset(offset int64) SearchBuilder {
+	b.request.Offset = offset
+	return b
+}
+
+// Filter sets the filter, a string, an array of strings or a filter.Expression
+func (b SearchBuilder) Filter(filter interface{}) SearchBuilder {
+	b.request.Filter = filter
+	return b
+}
+
+// Sort sets the sort criteria, as "attribute:asc" or "attribute:desc"
+func (b SearchBuilder) Sort(sort ...string) SearchBuilder {
+	b.request.Sort = copyStrings(sort)
+	return b
+}
+
+// Retrieve sets the attributes returned in the hits
+func (b SearchBuilder) Retrieve(attributes ...string) SearchBuilder {
+	b.request.AttributesToRetrieve = copyStrings(attributes)
+	return b
+}
+
+// Highlight sets the attributes to highlight
+func (b SearchBuilder) Highlight(attributes ...string) SearchBuilder {
+	b.request.AttributesToHighlight = copyStrings(attributes)
+	return b
+}
+
+// Crop sets the attributes to crop
+func (b SearchBuilder) Crop(attributes ...string) SearchBuilder {
+	b.request.AttributesToCrop = copyStrings(attributes)
+	return b
+}
+
+// CropLength sets the length of the cropped attributes
+func (b SearchBuilder) CropLength(length int64) SearchBuilder {
+	b.request.CropLength = length
+	return b
+}
+
+// Matches enables the _matchesInfo of the hits
+func (b SearchBuilder) Matches() SearchBuilder {
+	b.request.Matches = true
+	return b
+}
+
+// Facets sets the attributes of the facets distribution
+func (b SearchBuilder) Facets(attributes ...string) SearchBuilder {
+	b.request.FacetsDistribution = copyStrings(attributes)
+	return b
+}
+
+// Query returns the query of the search
+func (b SearchBuilder) Query() string {
+	return b.query
+}
+
+// Request returns the search as a SearchRequest, to be used with Search. An
+// explicit limit of 0 cannot be represented by a SearchRequest.
+func (b SearchBuilder) Request() SearchRequest {
+	request := b.request
+	request.PlaceholderSearch = b.placeholder
+	if b.limit != nil {
+		request.Limit = *b.limit
+	}
+	request.AttributesToRetrieve = copyStrings(request.AttributesToRetrieve)
+	request.AttributesToCrop = copyStrings(request.AttributesToCrop)
+	request.AttributesToHighlight = copyStrings(request.AttributesToHighlight)
+	request.FacetsDistribution = copyStrings(request.FacetsDistribution)
+	request.Sort = copyStrings(request.Sort)
+	return request
+}
+
+var sortCriterionRegexp = regexp.MustCompile(`^(_geoPoint\(\s*-?[0-9.]+\s*,\s*-?[0-9.]+\s*\)|[^\s:]+):(asc|desc)$`)
+
+// Validate checks the search before it is sent
+func (b SearchBuilder) Validate() error {
+	if b.limit != nil && *b.limit < 0 {
+		return fmt.Errorf("invalid search: negative limit %d", *b.limit)
+	}
+	if b.request.Offset < 0 {
+		return fmt.Errorf("invalid search: negative offset %d", b.request.Offset)
+	}
+	if b.request.CropLength < 0 {
+		return fmt.Errorf("invalid search: negative crop length %d", b.request.CropLength)
+	}
+	if b.request.CropLength != 0 && len(b.request.AttributesToCrop) == 0 {
+		return fmt.Errorf("invalid search: crop length without attributes to crop")
+	}
+	for _, criterion := range b.request.Sort {
+		if !sortCriterionRegexp.MatchString(criterion) {
+			return fmt.Errorf("invalid search: sort criterion %q is not \"attribute:asc\" or \"attribute:desc\"", criterion)
+		}
+	}
+	for _, attributes := range []struct {
+		name   string
+		values []string
+	}{
+		{"attributes to retrieve", b.request.AttributesToRetrieve},
+		{"attributes to highlight", b.request.AttributesToHighlight},
+		{"attributes to crop", b.request.AttributesToCrop},
+		{"facets", b.request.FacetsDistribution},
+	} {
+		for _, attribute := range attributes.values {
+			if attribute == "" {
+				return fmt.Errorf("invalid search: empty attribute name in %s", attributes.name)
+			}
+		}
+	}
+	switch f := b.request.Filter.(type) {
+	case string:
+		if _, err := filter.Parse(f); err != nil {
+			return errors.Wrap(err, "invalid search")
+		}
+	case filter.Expression:
+		if err := filter.Validate(f); err != nil {
+			return errors.Wrap(err, "invalid search")
+		}
+	}
+	return nil
+}
+
+// SearchWith validates and runs the search built by search
+func (i Index) SearchWith(search SearchBuilder) (*SearchResponse, error) {
+	if err := search.Validate(); err != nil {
+		return nil, err
+	}
+
+	request := search.Request()
+	params := searchPostRequestParams(search.query, &request)
+	if search.limit != nil {
+		params["limit"] = *search.limit
+	}
+	return i.searchWithParams(params, "SearchWith")
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
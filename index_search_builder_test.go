@@ -0,0 +1,133 @@
+package meilisearch
+
+import (
+	"testing"
+
+	"github.com/meilisearch/meilisearch-go/filter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchBuilder_Immutable(t *testing.T) {
+	base := NewSearch("prince").Limit(10).Sort("year:asc")
+	sorted := base.Sort("year:desc", "title:asc")
+	paged := base.Offset(20)
+
+	require.Equal(t, []string{"year:asc"}, base.Request().Sort)
+	require.Equal(t, int64(0), base.Request().Offset)
+	require.Equal(t, []string{"year:desc", "title:asc"}, sorted.Request().Sort)
+	require.Equal(t, int64(20), paged.Request().Offset)
+
+	// Arguments and returned requests are copied
+	attributes := []string{"title"}
+	search := NewSearch("prince").Highlight(attributes...)
+	attributes[0] = "tag"
+	request := search.Request()
+	request.AttributesToHighlight[0] = "year"
+	require.Equal(t, []string{"title"}, search.Request().AttributesToHighlight)
+}
+
+func TestSearchBuilder_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		search  SearchBuilder
+		wantErr bool
+	}{
+		{name: "TestValidSearch", search: NewSearch("prince").Limit(0).Offset(10).Crop("title").CropLength(5).Sort("year:desc", "_geoPoint(48.8, -2.3):asc").Filter("tag = Tale AND year > 1900")},
+		{name: "TestValidFilterExpression", search: NewPlaceholderSearch().Filter(filter.Eq("tag", "Tale"))},
+		{name: "TestNegativeLimit", search: NewSearch("prince").Limit(-1), wantErr: true},
+		{name: "TestNegativeOffset", search: NewSearch("prince").Offset(-1), wantErr: true},
+		{name: "TestNegativeCropLength", search: NewSearch("prince").Crop("title").CropLength(-1), wantErr: true},
+		{name: "TestCropLengthWithoutCrop", search: NewSearch("prince").CropLength(10), wantErr: true},
+		{name: "TestInvalidSortOrder", search: NewSearch("prince").Sort("year:descending"), wantErr: true},
+		{name: "TestSortWithoutOrder", search: NewSearch("prince").Sort("year"), wantErr: true},
+		{name: "TestEmptyAttribute", search: NewSearch("prince").Retrieve("title", ""), wantErr: true},
+		{name: "TestInvalidFilter", search: NewSearch("prince").Filter("tag = "), wantErr: true},
+		{name: "TestNilFilterValue", search: NewSearch("prince").Filter(filter.Eq("tag", nil)), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.search.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIndex_SearchDoesNotMutateRequest(t *testing.T) {
+	request := &SearchRequest{}
+	params := searchPostRequestParams("prince", request)
+	require.Equal(t, &SearchRequest{}, request)
+	require.Equal(t, map[string]interface{}{"q": "prince"}, params)
+}
+
+func TestIndex_SearchNilFilterValue(t *testing.T) {
+	for _, c := range []*Client{
+		NewClient(ClientConfig{Host: "http://localhost:7700"}),
+		NewClient(ClientConfig{Host: "http://localhost:7700", SearchCache: &SearchCacheConfig{}}),
+	} {
+		_, err := c.Index("indexUID").Search("prince", &SearchRequest{Filter: filter.Eq("tag", nil)})
+		require.Error(t, err)
+		require.Equal(t, ErrCodeMarshalRequest, err.(*Error).ErrCode)
+	}
+}
+
+func TestIndex_SearchEmptyFilterExpression(t *testing.T) {
+	for _, f := range []interface{}{filter.And(), filter.Or(), filter.And(filter.Or())} {
+		params := searchPostRequestParams("prince", &SearchRequest{Filter: f})
+		require.Equal(t, map[string]interface{}{"q": "prince"}, params)
+	}
+	params := searchPostRequestParams("prince", &SearchRequest{Filter: filter.And(filter.Eq("tag", "Tale"))})
+	require.Equal(t, filter.And(filter.Eq("tag", "Tale")), params["filter"])
+}
+
+func TestIndex_SearchWith(t *testing.T) {
+	tests := []struct {
+		name       string
+		search     SearchBuilder
+		wantNbHits int64
+		wantHits   int
+		wantLimit  int64
+	}{
+		{
+			name:       "TestIndexSearchWithBuilder",
+			search:     NewSearch("prince").Retrieve("title"),
+			wantNbHits: 2,
+			wantHits:   2,
+			wantLimit:  20,
+		},
+		{
+			name:       "TestIndexSearchWithZeroLimit",
+			search:     NewSearch("prince").Limit(0),
+			wantNbHits: 2,
+			wantHits:   0,
+			wantLimit:  0,
+		},
+		{
+			name:       "TestIndexSearchWithPlaceholderAndOffset",
+			search:     NewPlaceholderSearch().Limit(3).Offset(4),
+			wantNbHits: 6,
+			wantHits:   2,
+			wantLimit:  3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpBasicIndex()
+			c := defaultClient
+			i := c.Index("indexUID")
+			t.Cleanup(cleanup(c))
+
+			got, err := i.SearchWith(tt.search)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantNbHits, got.NbHits)
+			require.Len(t, got.Hits, tt.wantHits)
+			require.Equal(t, tt.wantLimit, got.Limit)
+		})
+	}
+
+	_, err := defaultClient.Index("indexUID").SearchWith(NewSearch("prince").Offset(-1))
+	require.Error(t, err)
+}
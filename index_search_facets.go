@@ -0,0 +1,154 @@
+package meilisearch
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/meilisearch/meilisearch-go/filter"
+)
+
+// FacetSelection holds the facet values selected by a user, by facet
+// attribute
+type FacetSelection map[string][]string
+
+// FacetValue is a facet value and the number of hits having it
+type FacetValue struct {
+	Value string
+	Count int64
+}
+
+// Filter returns the filter, in array form, matching the selection: the
+// values of a facet are combined with OR and the facets with AND. It is nil
+// when no value is selected.
+func (s FacetSelection) Filter() []interface{} {
+	return s.filterExcept("")
+}
+
+// filterExcept is Filter ignoring the values selected for a facet
+func (s FacetSelection) filterExcept(except string) []interface{} {
+	attributes := make([]string, 0, len(s))
+	for attribute := range s {
+		if attribute != except && len(s[attribute]) != 0 {
+			attributes = append(attributes, attribute)
+		}
+	}
+	sort.Strings(attributes)
+
+	var array []interface{}
+	for _, attribute := range attributes {
+		conditions := make([]string, len(s[attribute]))
+		for j, value := range s[attribute] {
+			conditions[j] = filter.Eq(attribute, value).String()
+		}
+		array = append(array, conditions)
+	}
+	return array
+}
+
+// SearchWithFacetSelection searches with the filter of the selection added to
+// request.Filter. The counts of every selected facet are disjunctive: they
+// ignore the values selected for the facet itself, as facet checkboxes
+// combined with OR expect. They are computed with an extra search per facet.
+func (i Index) SearchWithFacetSelection(query string, request *SearchRequest, selection FacetSelection) (*SearchResponse, error) {
+	search := *request
+	f, err := arrayFilter(request.Filter, selection.Filter())
+	if err != nil {
+		return nil, err
+	}
+	search.Filter = f
+	resp, err := i.Search(query, &search)
+	if err != nil {
+		return nil, err
+	}
+	// The distribution may be shared, with the search cache for instance
+	resp.FacetsDistribution = copyFacetsDistribution(resp.FacetsDistribution)
+
+	for attribute, values := range selection {
+		if len(values) == 0 {
+			continue
+		}
+		facetSearch := *request
+		facetSearch.Limit = 1
+		facetSearch.Offset = 0
+		facetSearch.FacetsDistribution = []string{attribute}
+		if facetSearch.Filter, err = arrayFilter(request.Filter, selection.filterExcept(attribute)); err != nil {
+			return nil, err
+		}
+		facetResp, err := i.Search(query, &facetSearch)
+		if err != nil {
+			return nil, err
+		}
+		if resp.FacetsDistribution == nil {
+			resp.FacetsDistribution = map[string]map[string]int64{}
+		}
+		resp.FacetsDistribution[attribute] = facetResp.FacetsDistribution[attribute]
+		resp.ExhaustiveFacetsCount = resp.ExhaustiveFacetsCount && facetResp.ExhaustiveFacetsCount
+	}
+	return resp, nil
+}
+
+// arrayFilter adds conditions, in array form, to a filter given as a string,
+// an array or a filter.Expression
+func arrayFilter(f interface{}, conditions []interface{}) (interface{}, error) {
+	if len(conditions) == 0 {
+		return f, nil
+	}
+	var array []interface{}
+	switch v := f.(type) {
+	case nil:
+	case string:
+		if v != "" {
+			array = append(array, v)
+		}
+	case []string:
+		for _, s := range v {
+			array = append(array, s)
+		}
+	case []interface{}:
+		array = append(array, v...)
+	case filter.Expression:
+		if err := filter.Validate(v); err != nil {
+			return nil, err
+		}
+		if s := v.String(); s != "" {
+			array = append(array, s)
+		}
+	case fmt.Stringer:
+		if s := v.String(); s != "" {
+			array = append(array, s)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported filter type %T", f)
+	}
+	return append(array, conditions...), nil
+}
+
+// FacetValuesByCount returns the values of a facet distribution sorted by
+// decreasing count, values with the same count are sorted by name
+func FacetValuesByCount(distribution map[string]int64) []FacetValue {
+	values := facetValues(distribution)
+	sort.Slice(values, func(a, b int) bool {
+		if values[a].Count != values[b].Count {
+			return values[a].Count > values[b].Count
+		}
+		return values[a].Value < values[b].Value
+	})
+	return values
+}
+
+// FacetValuesByName returns the values of a facet distribution sorted by name
+func FacetValuesByName(distribution map[string]int64) []FacetValue {
+	values := facetValues(distribution)
+	sort.Slice(values, func(a, b int) bool {
+		return values[a].Value < values[b].Value
+	})
+	return values
+}
+
+func facetValues(distribution map[string]int64) []FacetValue {
+	values := make([]FacetValue, 0, len(distribution))
+	for value, count := range distribution {
+		values = append(values, FacetValue{Value: value, Count: count})
+	}
+	return values
+}
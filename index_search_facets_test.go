@@ -0,0 +1,149 @@
+package meilisearch
+
+import (
+	"testing"
+
+	"github.com/meilisearch/meilisearch-go/filter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFacetSelection_Filter(t *testing.T) {
+	tests := []struct {
+		name      string
+		selection FacetSelection
+		want      []interface{}
+	}{
+		{
+			name:      "TestFacetSelectionFilterEmpty",
+			selection: FacetSelection{"tag": nil},
+			want:      nil,
+		},
+		{
+			name:      "TestFacetSelectionFilterOneFacet",
+			selection: FacetSelection{"tag": {"Novel", "Tale"}},
+			want:      []interface{}{[]string{`tag = "Novel"`, `tag = "Tale"`}},
+		},
+		{
+			name:      "TestFacetSelectionFilterSeveralFacets",
+			selection: FacetSelection{"year": {"1865"}, "tag": {"Epic fantasy"}, "genre": {}},
+			want: []interface{}{
+				[]string{`tag = "Epic fantasy"`},
+				[]string{`year = "1865"`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.selection.Filter())
+		})
+	}
+}
+
+func Test_arrayFilter(t *testing.T) {
+	conditions := []interface{}{[]string{`tag = "Novel"`}}
+	tests := []struct {
+		name    string
+		filter  interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:   "TestArrayFilterNil",
+			filter: nil,
+			want:   []interface{}{[]string{`tag = "Novel"`}},
+		},
+		{
+			name:   "TestArrayFilterString",
+			filter: "year > 1900",
+			want:   []interface{}{"year > 1900", []string{`tag = "Novel"`}},
+		},
+		{
+			name:   "TestArrayFilterStringArray",
+			filter: []string{"year > 1900"},
+			want:   []interface{}{"year > 1900", []string{`tag = "Novel"`}},
+		},
+		{
+			name:   "TestArrayFilterArray",
+			filter: []interface{}{[]string{"year > 1900", "year < 1800"}},
+			want:   []interface{}{[]string{"year > 1900", "year < 1800"}, []string{`tag = "Novel"`}},
+		},
+		{
+			name:   "TestArrayFilterExpression",
+			filter: filter.Gt("year", 1900),
+			want:   []interface{}{"year > 1900", []string{`tag = "Novel"`}},
+		},
+		{
+			name:    "TestArrayFilterUnsupported",
+			filter:  1900,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := arrayFilter(tt.filter, conditions)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFacetValues(t *testing.T) {
+	distribution := map[string]int64{"Tale": 2, "Novel": 5, "Epic": 2, "Satiric": 1}
+	require.Equal(t, []FacetValue{
+		{Value: "Novel", Count: 5},
+		{Value: "Epic", Count: 2},
+		{Value: "Tale", Count: 2},
+		{Value: "Satiric", Count: 1},
+	}, FacetValuesByCount(distribution))
+	require.Equal(t, []FacetValue{
+		{Value: "Epic", Count: 2},
+		{Value: "Novel", Count: 5},
+		{Value: "Satiric", Count: 1},
+		{Value: "Tale", Count: 2},
+	}, FacetValuesByName(distribution))
+	require.Empty(t, FacetValuesByCount(nil))
+}
+
+func TestIndex_SearchWithFacetSelection(t *testing.T) {
+	SetUpIndexForFaceting()
+	c := defaultClient
+	i := c.Index("indexUID")
+	t.Cleanup(cleanup(c))
+
+	update, err := i.UpdateFilterableAttributes(&[]string{"tag", "year"})
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+
+	request := &SearchRequest{
+		PlaceholderSearch:  true,
+		Filter:             "year > 1800",
+		FacetsDistribution: []string{"tag", "year"},
+	}
+	got, err := i.SearchWithFacetSelection("", request, FacetSelection{"tag": {"Novel", "Tale"}})
+	require.NoError(t, err)
+	require.Equal(t, "year > 1800", request.Filter)
+
+	// Novels and tales published after 1800
+	require.Equal(t, int64(7), got.NbHits)
+	// The counts of tag ignore the selected tags but not the request filter
+	require.Equal(t, map[string]int64{
+		"Romance":              1,
+		"Tale":                 2,
+		"Epic fantasy":         3,
+		"Tragedy":              2,
+		"Modernist literature": 1,
+		"Novel":                5,
+		"Historical fiction":   1,
+		"Crime fiction":        1,
+	}, got.FacetsDistribution["tag"])
+	// The counts of year are restricted by the selected tags
+	var years int64
+	for _, count := range got.FacetsDistribution["year"] {
+		years += count
+	}
+	require.Equal(t, int64(7), years)
+}
@@ -0,0 +1,186 @@
+package meilisearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/meilisearch/meilisearch-go/filter"
+	"github.com/pkg/errors"
+)
+
+// GeoAttribute is the reserved attribute holding the location of documents
+const GeoAttribute = "_geo"
+
+// GeoPoint is the location of a document, stored in its _geo field:
+//
+//	type Restaurant struct {
+//		ID   int       `json:"id"`
+//		Name string    `json:"name"`
+//		Geo  *GeoPoint `json:"_geo,omitempty"`
+//	}
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// UnmarshalJSON decodes a point whose coordinates are numbers or, as
+// Meilisearch also accepts them, strings
+func (p *GeoPoint) UnmarshalJSON(data []byte) error {
+	var point struct {
+		Lat json.Number `json:"lat"`
+		Lng json.Number `json:"lng"`
+	}
+	if err := json.Unmarshal(data, &point); err != nil {
+		return errors.Wrap(err, "could not decode _geo")
+	}
+	lat, err := point.Lat.Float64()
+	if err != nil {
+		return errors.Wrapf(err, "invalid _geo latitude %q", point.Lat)
+	}
+	lng, err := point.Lng.Float64()
+	if err != nil {
+		return errors.Wrapf(err, "invalid _geo longitude %q", point.Lng)
+	}
+	p.Lat, p.Lng = lat, lng
+	return nil
+}
+
+// Radius matches the documents within meters of the point
+func (p GeoPoint) Radius(meters float64) filter.GeoRadiusExpression {
+	return filter.GeoRadius(p.Lat, p.Lng, meters)
+}
+
+// Asc is the sort criterion of the documents from the closest to the point
+// to the farthest
+func (p GeoPoint) Asc() string {
+	return GeoPointAsc(p.Lat, p.Lng)
+}
+
+// Desc is the sort criterion of the documents from the farthest to the point
+// to the closest
+func (p GeoPoint) Desc() string {
+	return GeoPointDesc(p.Lat, p.Lng)
+}
+
+// GeoPointAsc returns the sort criterion "_geoPoint(lat, lng):asc"
+func GeoPointAsc(lat, lng float64) string {
+	return geoPointSort(lat, lng, "asc")
+}
+
+// GeoPointDesc returns the sort criterion "_geoPoint(lat, lng):desc"
+func GeoPointDesc(lat, lng float64) string {
+	return geoPointSort(lat, lng, "desc")
+}
+
+func geoPointSort(lat, lng float64, order string) string {
+	return "_geoPoint(" + strconv.FormatFloat(lat, 'f', -1, 64) + ", " + strconv.FormatFloat(lng, 'f', -1, 64) + "):" + order
+}
+
+// GeoHit can be embedded in the hit type given to SearchInto to decode the
+// location of the hits and, for searches sorted by _geoPoint, their distance
+// in meters to the point. It must not be embedded in a type already having a
+// _geo field, encoding/json ignores fields with the same name at the same
+// depth.
+type GeoHit struct {
+	Geo         *GeoPoint `json:"_geo,omitempty"`
+	GeoDistance *float64  `json:"_geoDistance,omitempty"`
+}
+
+// HitGeo reads the _geo and _geoDistance fields of a hit of
+// SearchResponse.Hits
+func HitGeo(hit interface{}) (*GeoHit, error) {
+	document, ok := hit.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("hit must be a map[string]interface{}, got %T", hit)
+	}
+
+	geo := &GeoHit{}
+	if g, ok := document[GeoAttribute]; ok && g != nil {
+		point, ok := g.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("could not decode _geo: expected an object, got %T", g)
+		}
+		lat, err := geoCoordinate(point["lat"])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid _geo latitude")
+		}
+		lng, err := geoCoordinate(point["lng"])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid _geo longitude")
+		}
+		geo.Geo = &GeoPoint{Lat: lat, Lng: lng}
+	}
+	if d, ok := document["_geoDistance"]; ok && d != nil {
+		distance, err := hitFloat(d)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid _geoDistance")
+		}
+		geo.GeoDistance = &distance
+	}
+	return geo, nil
+}
+
+// geoCoordinate reads a coordinate of a point decoded as a generic map, given
+// as a number or a string
+func geoCoordinate(value interface{}) (float64, error) {
+	if s, ok := value.(string); ok {
+		return strconv.ParseFloat(s, 64)
+	}
+	return hitFloat(value)
+}
+
+// GeoSettingsError is returned by CheckGeoSettings when _geo is missing from
+// the filterable or sortable attributes of an index
+type GeoSettingsError struct {
+	IndexUID      string
+	NotFilterable bool
+	NotSortable   bool
+}
+
+func (e *GeoSettingsError) Error() string {
+	var missing []string
+	if e.NotFilterable {
+		missing = append(missing, "filterable")
+	}
+	if e.NotSortable {
+		missing = append(missing, "sortable")
+	}
+	return fmt.Sprintf("_geo is not a %s attribute of index %q", strings.Join(missing, " and "), e.IndexUID)
+}
+
+// CheckGeoSettings returns a *GeoSettingsError when _geo is not in the
+// filterable attributes of the index, if filterable is true, or in its
+// sortable attributes, if sortable is true. Meilisearch rejects _geoRadius
+// filters and _geoPoint sorts otherwise.
+func (i Index) CheckGeoSettings(filterable, sortable bool) error {
+	geoErr := &GeoSettingsError{IndexUID: i.UID}
+	if filterable {
+		attributes, err := i.GetFilterableAttributes()
+		if err != nil {
+			return err
+		}
+		geoErr.NotFilterable = !containsString(*attributes, GeoAttribute)
+	}
+	if sortable {
+		attributes, err := i.GetSortableAttributes()
+		if err != nil {
+			return err
+		}
+		geoErr.NotSortable = !containsString(*attributes, GeoAttribute)
+	}
+	if geoErr.NotFilterable || geoErr.NotSortable {
+		return geoErr
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package meilisearch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type docTestRestaurant struct {
+	ID   int       `json:"id"`
+	Name string    `json:"name"`
+	Geo  *GeoPoint `json:"_geo,omitempty"`
+}
+
+func TestGeoPoint_JSON(t *testing.T) {
+	data, err := json.Marshal(docTestRestaurant{ID: 1, Name: "Nàpiz", Geo: &GeoPoint{Lat: 45.4777599, Lng: 9.1967508}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id": 1, "name": "Nàpiz", "_geo": {"lat": 45.4777599, "lng": 9.1967508}}`, string(data))
+
+	tests := []struct {
+		name    string
+		data    string
+		want    GeoPoint
+		wantErr bool
+	}{
+		{name: "TestGeoPointNumbers", data: `{"lat": 45.4777599, "lng": 9.1967508}`, want: GeoPoint{Lat: 45.4777599, Lng: 9.1967508}},
+		{name: "TestGeoPointStrings", data: `{"lat": "45.4777599", "lng": "-9.1967508"}`, want: GeoPoint{Lat: 45.4777599, Lng: -9.1967508}},
+		{name: "TestGeoPointMissingLongitude", data: `{"lat": 45.4777599}`, wantErr: true},
+		{name: "TestGeoPointInvalidLatitude", data: `{"lat": "north", "lng": 9.1967508}`, wantErr: true},
+		{name: "TestGeoPointNotAnObject", data: `[45.4777599, 9.1967508]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got GeoPoint
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGeoPoint_FilterAndSort(t *testing.T) {
+	point := GeoPoint{Lat: 45.4777599, Lng: 9.1967508}
+	require.Equal(t, "_geoRadius(45.4777599, 9.1967508, 2000)", point.Radius(2000).String())
+	require.Equal(t, "_geoPoint(45.4777599, 9.1967508):asc", point.Asc())
+	require.Equal(t, "_geoPoint(45.4777599, 9.1967508):desc", point.Desc())
+	require.Equal(t, "_geoPoint(-0.5, 12):asc", GeoPointAsc(-0.5, 12))
+	require.NoError(t, NewPlaceholderSearch().Sort(point.Asc()).Filter(point.Radius(2000)).Validate())
+}
+
+func TestHitGeo(t *testing.T) {
+	got, err := HitGeo(map[string]interface{}{
+		"id":           float64(1),
+		"_geo":         map[string]interface{}{"lat": float64(45.4777599), "lng": float64(9.1967508)},
+		"_geoDistance": float64(1532),
+	})
+	require.NoError(t, err)
+	require.Equal(t, &GeoPoint{Lat: 45.4777599, Lng: 9.1967508}, got.Geo)
+	require.NotNil(t, got.GeoDistance)
+	require.Equal(t, float64(1532), *got.GeoDistance)
+
+	got, err = HitGeo(map[string]interface{}{"id": float64(1)})
+	require.NoError(t, err)
+	require.Equal(t, &GeoHit{}, got)
+
+	_, err = HitGeo("Nàpiz")
+	require.Error(t, err)
+}
+
+func TestIndex_GeoSearch(t *testing.T) {
+	c := defaultClient
+	i := c.Index("restaurants")
+	t.Cleanup(cleanup(c))
+
+	update, err := i.AddDocuments([]docTestRestaurant{
+		{ID: 1, Name: "Nàpiz' Milano", Geo: &GeoPoint{Lat: 45.4777599, Lng: 9.1967508}},
+		{ID: 2, Name: "Bouillon Pigalle", Geo: &GeoPoint{Lat: 48.8826517, Lng: 2.3352748}},
+		{ID: 3, Name: "Artico Gelateria Tradizionale", Geo: &GeoPoint{Lat: 45.4632046, Lng: 9.1719421}},
+		{ID: 4, Name: "Nowhere"},
+	}, "id")
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+
+	geoErr, ok := i.CheckGeoSettings(true, true).(*GeoSettingsError)
+	require.True(t, ok)
+	require.True(t, geoErr.NotFilterable)
+	require.True(t, geoErr.NotSortable)
+
+	update, err = i.UpdateFilterableAttributes(&[]string{GeoAttribute})
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+	require.NoError(t, i.CheckGeoSettings(true, false))
+	geoErr, ok = i.CheckGeoSettings(true, true).(*GeoSettingsError)
+	require.True(t, ok)
+	require.False(t, geoErr.NotFilterable)
+	require.True(t, geoErr.NotSortable)
+
+	update, err = i.UpdateSortableAttributes(&[]string{GeoAttribute})
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+	require.NoError(t, i.CheckGeoSettings(true, true))
+
+	milan := GeoPoint{Lat: 45.4628246, Lng: 9.1076927}
+	var hits []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+		GeoHit
+	}
+	_, err = i.SearchInto("", &SearchRequest{
+		PlaceholderSearch: true,
+		Filter:            milan.Radius(10000),
+		Sort:              []string{milan.Asc()},
+	}, &hits)
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+	require.Equal(t, 3, hits[0].ID)
+	require.Equal(t, 1, hits[1].ID)
+	require.Equal(t, &GeoPoint{Lat: 45.4632046, Lng: 9.1719421}, hits[0].Geo)
+	require.NotNil(t, hits[0].GeoDistance)
+	require.Less(t, *hits[0].GeoDistance, *hits[1].GeoDistance)
+}
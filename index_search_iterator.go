@@ -0,0 +1,175 @@
+package meilisearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// DefaultSearchPageSize is the number of hits fetched per search by
+// SearchAll when no page size is given
+const DefaultSearchPageSize int64 = 100
+
+// maxHitsPerQuery is the maximum number of hits Meilisearch returns for a
+// query, whatever the offset and limit
+const maxHitsPerQuery int64 = 1000
+
+// SearchAllOptions configure SearchAll
+type SearchAllOptions struct {
+
+	// PageSize is the number of hits fetched per search.
+	// Default to DefaultSearchPageSize.
+	PageSize int64
+
+	// MaxHits stops the iteration after MaxHits hits.
+	// Default to 0, every hit is returned.
+	MaxHits int64
+}
+
+// SearchPaginationError is returned by a SearchIterator when Meilisearch stops
+// returning hits before NbHits are read, as it does past its maximum number of
+// hits per query. The iteration stops instead of silently returning part of
+// the hits. It is not returned when NbHits is an estimate, unless the hits
+// stopped at the maximum.
+type SearchPaginationError struct {
+	// Offset is the number of hits read
+	Offset int64
+	// NbHits is the number of hits announced by Meilisearch
+	NbHits int64
+}
+
+func (e *SearchPaginationError) Error() string {
+	return fmt.Sprintf("search results are truncated: Meilisearch returned %d of %d hits, "+
+		"narrow the query with a filter or set SearchAllOptions.MaxHits", e.Offset, e.NbHits)
+}
+
+// SearchIterator walks through the hits of a search page by page:
+//
+//	it := index.SearchAll(ctx, "prince", &SearchRequest{}, nil)
+//	for it.Next() {
+//		var book Book
+//		if err := it.Scan(&book); err != nil {
+//			...
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type SearchIterator struct {
+	ctx   context.Context
+	index Index
+
+	// fetchPage returns the next page of hits, done once it is the last one.
+	// An error returned with hits is reported once they are read.
+	fetchPage func() (hits []json.RawMessage, done bool, err error)
+
+	page     []json.RawMessage
+	position int
+	hit      json.RawMessage
+	resp     *SearchResponse
+	done     bool
+	pending  error
+	err      error
+}
+
+// SearchAll returns an iterator over every hit of query, fetched with
+// searches of opts.PageSize hits starting at request.Offset. request.Limit is
+// ignored and request is not modified.
+func (i Index) SearchAll(ctx context.Context, query string, request *SearchRequest, opts *SearchAllOptions) *SearchIterator {
+	o := SearchAllOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.PageSize <= 0 {
+		o.PageSize = DefaultSearchPageSize
+	}
+
+	it := &SearchIterator{ctx: ctx, index: i}
+	base := *request
+	// offset of the next page, relative to base.Offset
+	offset := int64(0)
+	it.fetchPage = func() ([]json.RawMessage, bool, error) {
+		limit := o.PageSize
+		if o.MaxHits > 0 {
+			if remaining := o.MaxHits - offset; remaining < limit {
+				limit = remaining
+			}
+			if limit <= 0 {
+				return nil, true, nil
+			}
+		}
+
+		request := base
+		request.Offset += offset
+		request.Limit = limit
+		resp, hits, err := i.SearchRaw(query, &request)
+		if err != nil {
+			return nil, true, err
+		}
+		it.resp = resp
+		offset += int64(len(hits))
+
+		if int64(len(hits)) < limit {
+			return hits, true, paginationError(base.Offset+offset, resp)
+		}
+		return hits, false, nil
+	}
+	return it
+}
+
+// paginationError returns the error of a search whose hits stopped after
+// read hits. A short page before NbHits is reached means Meilisearch does not
+// return more hits for this query, when NbHits is exact or when the maximum
+// number of hits was read. An estimated NbHits may just be too high.
+func paginationError(read int64, resp *SearchResponse) error {
+	if read < resp.NbHits && (resp.ExhaustiveNbHits || read >= maxHitsPerQuery) {
+		return &SearchPaginationError{Offset: read, NbHits: resp.NbHits}
+	}
+	return nil
+}
+
+// Next moves to the next hit, it returns false once every hit has been read
+// or if an error occurred.
+func (it *SearchIterator) Next() bool {
+	for it.position == len(it.page) {
+		if it.pending != nil {
+			it.err, it.pending = it.pending, nil
+		}
+		if it.err != nil || it.done {
+			it.hit = nil
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			continue
+		}
+		it.page, it.done, it.pending = it.fetchPage()
+		it.position = 0
+	}
+	it.hit = it.page[it.position]
+	it.position++
+	return true
+}
+
+// Raw returns the current hit as raw JSON
+func (it *SearchIterator) Raw() json.RawMessage {
+	return it.hit
+}
+
+// Scan decodes the current hit into hitPtr
+func (it *SearchIterator) Scan(hitPtr interface{}) error {
+	if it.hit == nil {
+		return fmt.Errorf("no current hit, Next must return true before Scan is called")
+	}
+	return it.index.client.jsonCodec().Unmarshal(it.hit, hitPtr)
+}
+
+// Response returns the metadata of the last page fetched, its Hits are nil
+func (it *SearchIterator) Response() *SearchResponse {
+	return it.resp
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *SearchIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,157 @@
+package meilisearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_SearchAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		request  SearchRequest
+		opts     *SearchAllOptions
+		wantHits int
+	}{
+		{
+			name:     "TestIndexSearchAllPlaceholder",
+			request:  SearchRequest{PlaceholderSearch: true},
+			opts:     &SearchAllOptions{PageSize: 3},
+			wantHits: 20,
+		},
+		{
+			name:     "TestIndexSearchAllDefaultPageSize",
+			request:  SearchRequest{PlaceholderSearch: true, Limit: 1},
+			opts:     nil,
+			wantHits: 20,
+		},
+		{
+			name:     "TestIndexSearchAllWithOffset",
+			request:  SearchRequest{PlaceholderSearch: true, Offset: 15},
+			opts:     &SearchAllOptions{PageSize: 2},
+			wantHits: 5,
+		},
+		{
+			name:     "TestIndexSearchAllWithMaxHits",
+			request:  SearchRequest{PlaceholderSearch: true},
+			opts:     &SearchAllOptions{PageSize: 3, MaxHits: 7},
+			wantHits: 7,
+		},
+		{
+			name:     "TestIndexSearchAllWithQuery",
+			query:    "and",
+			request:  SearchRequest{},
+			opts:     &SearchAllOptions{PageSize: 1},
+			wantHits: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpIndexForFaceting()
+			c := defaultClient
+			i := c.Index("indexUID")
+			t.Cleanup(cleanup(c))
+
+			request := tt.request
+			it := i.SearchAll(context.Background(), tt.query, &request, tt.opts)
+			seen := map[int]bool{}
+			for it.Next() {
+				var book docTestBooks
+				require.NoError(t, it.Scan(&book))
+				require.False(t, seen[book.BookID], "hit %d returned twice", book.BookID)
+				seen[book.BookID] = true
+			}
+			require.NoError(t, it.Err())
+			require.Len(t, seen, tt.wantHits)
+			require.Equal(t, tt.request, request)
+			require.Error(t, it.Scan(&docTestBooks{}))
+		})
+	}
+}
+
+func TestIndex_SearchAllPaginationCap(t *testing.T) {
+	c := defaultClient
+	i := c.Index("searchallcap")
+	t.Cleanup(cleanup(c))
+
+	documents := make([]map[string]interface{}, 1005)
+	for j := range documents {
+		documents[j] = map[string]interface{}{"id": j, "title": "Book"}
+	}
+	update, err := i.AddDocuments(documents)
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+
+	it := i.SearchAll(context.Background(), "", &SearchRequest{PlaceholderSearch: true}, &SearchAllOptions{PageSize: 400})
+	hits := 0
+	for it.Next() {
+		hits++
+	}
+	require.Equal(t, 1000, hits)
+	paginationErr, ok := it.Err().(*SearchPaginationError)
+	require.True(t, ok)
+	require.Equal(t, int64(1000), paginationErr.Offset)
+	require.Equal(t, int64(1005), paginationErr.NbHits)
+
+	// MaxHits below the cap is not an error
+	it = i.SearchAll(context.Background(), "", &SearchRequest{PlaceholderSearch: true}, &SearchAllOptions{PageSize: 400, MaxHits: 900})
+	hits = 0
+	for it.Next() {
+		hits++
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, 900, hits)
+}
+
+func TestIndex_SearchAllCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it := defaultClient.Index("indexUID").SearchAll(ctx, "prince", &SearchRequest{}, nil)
+	require.False(t, it.Next())
+	require.Equal(t, context.Canceled, it.Err())
+}
+
+func Test_paginationError(t *testing.T) {
+	tests := []struct {
+		name    string
+		read    int64
+		resp    *SearchResponse
+		wantErr bool
+	}{
+		{
+			name: "TestPaginationComplete",
+			read: 20,
+			resp: &SearchResponse{NbHits: 20, ExhaustiveNbHits: true},
+		},
+		{
+			name:    "TestPaginationExhaustiveTruncated",
+			read:    20,
+			resp:    &SearchResponse{NbHits: 25, ExhaustiveNbHits: true},
+			wantErr: true,
+		},
+		{
+			name: "TestPaginationEstimatedNbHits",
+			read: 20,
+			resp: &SearchResponse{NbHits: 25},
+		},
+		{
+			name:    "TestPaginationEstimatedAtCap",
+			read:    1000,
+			resp:    &SearchResponse{NbHits: 1005},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := paginationError(tt.read, tt.resp)
+			if tt.wantErr {
+				require.Equal(t, &SearchPaginationError{Offset: tt.read, NbHits: tt.resp.NbHits}, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package meilisearch
+
+import (
+	"encoding/json"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Match is the position of a matched query word in an attribute, as returned
+// in the _matchesInfo of the hits. Start and Length are counted in bytes of
+// the UTF-8 attribute value.
+type Match struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// MatchesInfo is the _matchesInfo of a hit, the matches of every attribute
+type MatchesInfo map[string][]Match
+
+// FormattedHit holds the _formatted and _matchesInfo fields of a hit. It is
+// meant to be embedded in the hit type used with SearchInto, so they are
+// decoded alongside the document:
+//
+//	type BookHit struct {
+//		Book
+//		meilisearch.FormattedHit
+//	}
+//
+// Formatted is only set with AttributesToHighlight or AttributesToCrop, and
+// MatchesInfo with Matches.
+type FormattedHit struct {
+	Formatted   map[string]interface{} `json:"_formatted,omitempty"`
+	MatchesInfo MatchesInfo            `json:"_matchesInfo,omitempty"`
+}
+
+// FormattedString returns the formatted value of a string attribute
+func (h FormattedHit) FormattedString(attribute string) (string, bool) {
+	s, ok := h.Formatted[attribute].(string)
+	return s, ok
+}
+
+// HitFormatting reads the _formatted and _matchesInfo fields of a hit of
+// SearchResponse.Hits
+func HitFormatting(hit interface{}) (*FormattedHit, error) {
+	document, ok := hit.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("hit must be a map[string]interface{}, got %T", hit)
+	}
+
+	formatted := &FormattedHit{}
+	if f, ok := document["_formatted"].(map[string]interface{}); ok {
+		formatted.Formatted = f
+	}
+	if m, ok := document["_matchesInfo"]; ok && m != nil {
+		info, err := matchesInfo(m)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode _matchesInfo")
+		}
+		formatted.MatchesInfo = info
+	}
+	return formatted, nil
+}
+
+// matchesInfo types the _matchesInfo of a hit decoded as generic maps, with
+// numbers decoded as float64 or json.Number depending on the JSON codec
+func matchesInfo(value interface{}) (MatchesInfo, error) {
+	attributes, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("expected an object, got %T", value)
+	}
+	info := make(MatchesInfo, len(attributes))
+	for attribute, m := range attributes {
+		list, ok := m.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("matches of %q: expected an array, got %T", attribute, m)
+		}
+		matches := make([]Match, len(list))
+		for j, item := range list {
+			fields, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("match of %q: expected an object, got %T", attribute, item)
+			}
+			start, err := hitInt(fields["start"])
+			if err != nil {
+				return nil, errors.Wrapf(err, "start of match of %q", attribute)
+			}
+			length, err := hitInt(fields["length"])
+			if err != nil {
+				return nil, errors.Wrapf(err, "length of match of %q", attribute)
+			}
+			matches[j] = Match{Start: start, Length: length}
+		}
+		info[attribute] = matches
+	}
+	return info, nil
+}
+
+func hitInt(value interface{}) (int, error) {
+	number, err := hitFloat(value)
+	return int(number), err
+}
+
+// hitFloat reads a number of a hit decoded as a generic map
+func hitFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	default:
+		return 0, errors.Errorf("expected a number, got %T", value)
+	}
+}
+
+// HighlightSpan is a part of an attribute value, either matched by the query
+// or not. Start and End are the positions in runes of the span in the value.
+type HighlightSpan struct {
+	Text    string
+	Start   int
+	End     int
+	Matched bool
+}
+
+// Spans returns the HighlightSpans of text, the value of attribute
+func (m MatchesInfo) Spans(attribute string, text string) []HighlightSpan {
+	return HighlightSpans(text, m[attribute])
+}
+
+// HighlightSpans splits text into spans, alternating parts that are not
+// matched and parts that are. Together the spans cover the whole text.
+//
+// Overlapping or adjacent matches are merged. Matches are counted in bytes,
+// one that does not fall on rune boundaries is extended to the whole runes so
+// a multi-byte character is never split, and one beyond the end of text is
+// truncated.
+func HighlightSpans(text string, matches []Match) []HighlightSpan {
+	ranges := mergeMatches(text, matches)
+
+	var (
+		spans []HighlightSpan
+		bytes int // position in bytes in text
+		runes int // position in runes in text
+	)
+	add := func(end int, matched bool) {
+		if end <= bytes {
+			return
+		}
+		part := text[bytes:end]
+		n := utf8.RuneCountInString(part)
+		spans = append(spans, HighlightSpan{Text: part, Start: runes, End: runes + n, Matched: matched})
+		bytes = end
+		runes += n
+	}
+	for _, r := range ranges {
+		add(r[0], false)
+		add(r[1], true)
+	}
+	add(len(text), false)
+	return spans
+}
+
+// mergeMatches returns the sorted, non-overlapping byte ranges of matches
+// aligned on rune boundaries
+func mergeMatches(text string, matches []Match) [][2]int {
+	ranges := make([][2]int, 0, len(matches))
+	for _, m := range matches {
+		if m.Length <= 0 || m.Start < 0 || m.Start >= len(text) {
+			continue
+		}
+		start, end := m.Start, m.Start+m.Length
+		if end > len(text) {
+			end = len(text)
+		}
+		for start > 0 && !utf8.RuneStart(text[start]) {
+			start--
+		}
+		for end < len(text) && !utf8.RuneStart(text[end]) {
+			end++
+		}
+		ranges = append(ranges, [2]int{start, end})
+	}
+	sort.Slice(ranges, func(a, b int) bool {
+		return ranges[a][0] < ranges[b][0]
+	})
+
+	merged := ranges[:0]
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r[0] <= merged[n-1][1] {
+			if r[1] > merged[n-1][1] {
+				merged[n-1][1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
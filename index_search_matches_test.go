@@ -0,0 +1,138 @@
+package meilisearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightSpans(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		matches []Match
+		want    []HighlightSpan
+	}{
+		{
+			name:    "TestHighlightSpansASCII",
+			text:    "Le Petit Prince",
+			matches: []Match{{Start: 9, Length: 6}},
+			want: []HighlightSpan{
+				{Text: "Le Petit ", Start: 0, End: 9},
+				{Text: "Prince", Start: 9, End: 15, Matched: true},
+			},
+		},
+		{
+			name:    "TestHighlightSpansMultiByte",
+			text:    "Château de Versailles",
+			matches: []Match{{Start: 0, Length: 8}, {Start: 12, Length: 10}},
+			want: []HighlightSpan{
+				{Text: "Château", Start: 0, End: 7, Matched: true},
+				{Text: " de ", Start: 7, End: 11},
+				{Text: "Versailles", Start: 11, End: 21, Matched: true},
+			},
+		},
+		{
+			name: "TestHighlightSpansSplitRune",
+			text: "日本語のテキスト",
+			// Starts and ends in the middle of 本 and 語
+			matches: []Match{{Start: 4, Length: 3}},
+			want: []HighlightSpan{
+				{Text: "日", Start: 0, End: 1},
+				{Text: "本語", Start: 1, End: 3, Matched: true},
+				{Text: "のテキスト", Start: 3, End: 8},
+			},
+		},
+		{
+			name:    "TestHighlightSpansOverlappingAndUnsorted",
+			text:    "harry potter",
+			matches: []Match{{Start: 6, Length: 6}, {Start: 0, Length: 3}, {Start: 2, Length: 4}},
+			want: []HighlightSpan{
+				{Text: "harry potter", Start: 0, End: 12, Matched: true},
+			},
+		},
+		{
+			name:    "TestHighlightSpansOutOfRange",
+			text:    "hobbit",
+			matches: []Match{{Start: 3, Length: 10}, {Start: 20, Length: 2}, {Start: 1, Length: 0}},
+			want: []HighlightSpan{
+				{Text: "hob", Start: 0, End: 3},
+				{Text: "bit", Start: 3, End: 6, Matched: true},
+			},
+		},
+		{
+			name:    "TestHighlightSpansNoMatch",
+			text:    "hobbit",
+			matches: nil,
+			want:    []HighlightSpan{{Text: "hobbit", Start: 0, End: 6}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, HighlightSpans(tt.text, tt.matches))
+		})
+	}
+}
+
+func TestHitFormatting(t *testing.T) {
+	hit := map[string]interface{}{
+		"book_id": float64(456),
+		"title":   "Le Petit Prince",
+		"_formatted": map[string]interface{}{
+			"book_id": "456",
+			"title":   "Le Petit <em>Prince</em>",
+		},
+		"_matchesInfo": map[string]interface{}{
+			"title": []interface{}{
+				map[string]interface{}{"start": float64(9), "length": float64(6)},
+			},
+		},
+	}
+
+	got, err := HitFormatting(hit)
+	require.NoError(t, err)
+	require.Equal(t, MatchesInfo{"title": {{Start: 9, Length: 6}}}, got.MatchesInfo)
+	title, ok := got.FormattedString("title")
+	require.True(t, ok)
+	require.Equal(t, "Le Petit <em>Prince</em>", title)
+	_, ok = got.FormattedString("year")
+	require.False(t, ok)
+
+	_, err = HitFormatting("Le Petit Prince")
+	require.Error(t, err)
+}
+
+func TestIndex_SearchIntoFormattedHits(t *testing.T) {
+	type bookHit struct {
+		BookID int    `json:"book_id"`
+		Title  string `json:"title"`
+		FormattedHit
+	}
+
+	SetUpBasicIndex()
+	c := defaultClient
+	i := c.Index("indexUID")
+	t.Cleanup(cleanup(c))
+
+	update, err := i.UpdateFilterableAttributes(&[]string{"book_id"})
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+
+	var hits []bookHit
+	_, err = i.SearchInto("prince", &SearchRequest{
+		AttributesToHighlight: []string{"title"},
+		Matches:               true,
+		Filter:                "book_id = 456",
+	}, &hits)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	require.Equal(t, "Le Petit Prince", hits[0].Title)
+
+	title, ok := hits[0].FormattedString("title")
+	require.True(t, ok)
+	require.Equal(t, "Le Petit <em>Prince</em>", title)
+	require.Equal(t, []HighlightSpan{
+		{Text: "Le Petit ", Start: 0, End: 9},
+		{Text: "Prince", Start: 9, End: 15, Matched: true},
+	}, hits[0].MatchesInfo.Spans("title", hits[0].Title))
+}
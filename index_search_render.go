@@ -0,0 +1,172 @@
+package meilisearch
+
+import (
+	"html/template"
+	"strings"
+	"unicode"
+)
+
+// RenderOptions configure the rendering of highlighted attributes. The tags
+// and crop marker are pointers so they can be set to "", to render plain text.
+type RenderOptions struct {
+
+	// PreTag is written before every match. Default to "<em>".
+	PreTag *string
+
+	// PostTag is written after every match. Default to "</em>".
+	PostTag *string
+
+	// CropLength is the maximum number of runes rendered around the first
+	// match, whole words are kept. Default to 0, the text is not cropped.
+	CropLength int
+
+	// CropMarker is written where the text is cropped. Default to "…".
+	CropMarker *string
+}
+
+// renderConfig is RenderOptions with the defaults applied
+type renderConfig struct {
+	preTag     string
+	postTag    string
+	cropLength int
+	cropMarker string
+}
+
+func (o *RenderOptions) withDefaults() renderConfig {
+	config := renderConfig{preTag: "<em>", postTag: "</em>", cropMarker: "…"}
+	if o == nil {
+		return config
+	}
+	if o.PreTag != nil {
+		config.preTag = *o.PreTag
+	}
+	if o.PostTag != nil {
+		config.postTag = *o.PostTag
+	}
+	if o.CropMarker != nil {
+		config.cropMarker = *o.CropMarker
+	}
+	config.cropLength = o.CropLength
+	return config
+}
+
+// RenderHighlightHTML renders text as HTML with its matches between the tags
+// of opts. Unlike the _formatted attributes, the text is HTML escaped so
+// documents containing markup, or literal tags, are rendered safely. The
+// tags and crop marker are written as is.
+func RenderHighlightHTML(text string, matches []Match, opts *RenderOptions) template.HTML {
+	return template.HTML(renderHighlight(text, matches, opts.withDefaults(), template.HTMLEscapeString))
+}
+
+// RenderHighlightText renders text with its matches between the tags of
+// opts, without any escaping.
+func RenderHighlightText(text string, matches []Match, opts *RenderOptions) string {
+	return renderHighlight(text, matches, opts.withDefaults(), func(s string) string { return s })
+}
+
+// HighlightFuncMap returns html/template functions rendering highlighted
+// attributes with RenderHighlightHTML:
+//
+//	{{ highlight .Title .MatchesInfo.title }}
+//	{{ highlightAttribute .MatchesInfo "title" .Title }}
+func HighlightFuncMap(opts *RenderOptions) template.FuncMap {
+	return template.FuncMap{
+		"highlight": func(text string, matches []Match) template.HTML {
+			return RenderHighlightHTML(text, matches, opts)
+		},
+		"highlightAttribute": func(matchesInfo MatchesInfo, attribute string, text string) template.HTML {
+			return RenderHighlightHTML(text, matchesInfo[attribute], opts)
+		},
+	}
+}
+
+func renderHighlight(text string, matches []Match, opts renderConfig, escape func(string) string) string {
+	spans := HighlightSpans(text, matches)
+	runes := []rune(text)
+	start, end := cropWindow(runes, spans, opts.cropLength)
+
+	var b strings.Builder
+	if strings.TrimSpace(string(runes[:start])) != "" {
+		b.WriteString(opts.cropMarker)
+	}
+	for _, span := range spans {
+		if span.End <= start || span.Start >= end {
+			continue
+		}
+		s, e := span.Start, span.End
+		if s < start {
+			s = start
+		}
+		if e > end {
+			e = end
+		}
+		part := escape(string(runes[s:e]))
+		if span.Matched {
+			b.WriteString(opts.preTag)
+			b.WriteString(part)
+			b.WriteString(opts.postTag)
+		} else {
+			b.WriteString(part)
+		}
+	}
+	if strings.TrimSpace(string(runes[end:])) != "" {
+		b.WriteString(opts.cropMarker)
+	}
+	return b.String()
+}
+
+// cropWindow returns the rune range of at most length runes of text centred
+// on the first match, without cutting words. It is the whole text when
+// length is 0.
+func cropWindow(runes []rune, spans []HighlightSpan, length int) (int, int) {
+	n := len(runes)
+	if length <= 0 || n <= length {
+		return 0, n
+	}
+
+	matchStart, matchEnd := 0, 0
+	for _, span := range spans {
+		if span.Matched {
+			matchStart, matchEnd = span.Start, span.End
+			break
+		}
+	}
+	if matchEnd-matchStart >= length {
+		return matchStart, matchEnd
+	}
+
+	start := matchStart - (length-(matchEnd-matchStart))/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + length
+	if end > n {
+		end = n
+		start = n - length
+	}
+
+	// Move the bounds inwards to the closest word boundaries
+	if start > 0 && !unicode.IsSpace(runes[start-1]) {
+		for j := start; j < matchStart; j++ {
+			if unicode.IsSpace(runes[j]) {
+				start = j + 1
+				break
+			}
+		}
+	}
+	if end < n && !unicode.IsSpace(runes[end]) {
+		for j := end - 1; j >= matchEnd; j-- {
+			if unicode.IsSpace(runes[j]) {
+				end = j
+				break
+			}
+		}
+	}
+	for start < end && unicode.IsSpace(runes[start]) {
+		start++
+	}
+	for end > start && unicode.IsSpace(runes[end-1]) {
+		end--
+	}
+	return start, end
+}
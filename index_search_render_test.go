@@ -0,0 +1,96 @@
+package meilisearch
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderHighlightHTML(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		matches []Match
+		opts    *RenderOptions
+		want    template.HTML
+	}{
+		{
+			name:    "TestRenderDefaultTags",
+			text:    "Le Petit Prince",
+			matches: []Match{{Start: 9, Length: 6}},
+			want:    "Le Petit <em>Prince</em>",
+		},
+		{
+			name:    "TestRenderEscapesDocument",
+			text:    "<em>Fake</em> & <script>Prince</script>",
+			matches: []Match{{Start: 24, Length: 6}},
+			want:    "&lt;em&gt;Fake&lt;/em&gt; &amp; &lt;script&gt;<em>Prince</em>&lt;/script&gt;",
+		},
+		{
+			name:    "TestRenderCustomTags",
+			text:    "Château de Versailles",
+			matches: []Match{{Start: 0, Length: 8}},
+			opts:    &RenderOptions{PreTag: stringPtr(`<mark class="hit">`), PostTag: stringPtr("</mark>")},
+			want:    `<mark class="hit">Château</mark> de Versailles`,
+		},
+		{
+			name:    "TestRenderCrop",
+			text:    "The quick brown fox jumps over the lazy dog by the river bank",
+			matches: []Match{{Start: 35, Length: 4}},
+			opts:    &RenderOptions{CropLength: 20, CropMarker: stringPtr("...")},
+			want:    "...the <em>lazy</em> dog by...",
+		},
+		{
+			name:    "TestRenderCropAtStart",
+			text:    "Harry Potter and the Half-Blood Prince",
+			matches: []Match{{Start: 0, Length: 5}},
+			opts:    &RenderOptions{CropLength: 14},
+			want:    "<em>Harry</em> Potter…",
+		},
+		{
+			name: "TestRenderCropWithoutMatch",
+			text: "Harry Potter and the Half-Blood Prince",
+			opts: &RenderOptions{CropLength: 16},
+			want: "Harry Potter and…",
+		},
+		{
+			name:    "TestRenderEmptyTags",
+			text:    "The quick brown fox jumps over the lazy dog by the river bank",
+			matches: []Match{{Start: 35, Length: 4}},
+			opts:    &RenderOptions{PreTag: stringPtr(""), PostTag: stringPtr(""), CropLength: 20, CropMarker: stringPtr("")},
+			want:    "the lazy dog by",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, RenderHighlightHTML(tt.text, tt.matches, tt.opts))
+		})
+	}
+}
+
+func TestRenderHighlightText(t *testing.T) {
+	got := RenderHighlightText("Pride & Prejudice", []Match{{Start: 8, Length: 9}}, &RenderOptions{PreTag: stringPtr("**"), PostTag: stringPtr("**")})
+	require.Equal(t, "Pride & **Prejudice**", got)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestHighlightFuncMap(t *testing.T) {
+	tmpl := template.Must(template.New("hit").Funcs(HighlightFuncMap(nil)).Parse(
+		`<li>{{ highlightAttribute .MatchesInfo "title" .Title }}</li><li>{{ highlight .Title nil }}</li>`))
+
+	b := new(bytes.Buffer)
+	err := tmpl.Execute(b, struct {
+		Title       string
+		MatchesInfo MatchesInfo
+	}{
+		Title:       "Le <Petit> Prince",
+		MatchesInfo: MatchesInfo{"title": {{Start: 11, Length: 6}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "<li>Le &lt;Petit&gt; <em>Prince</em></li><li>Le &lt;Petit&gt; Prince</li>", b.String())
+}
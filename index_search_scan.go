@@ -0,0 +1,234 @@
+package meilisearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/meilisearch/meilisearch-go/filter"
+	"github.com/pkg/errors"
+)
+
+// ErrScanStalled is returned by the iterator of ScanDocuments when more
+// documents share a value of the scanned attribute than Meilisearch returns
+// for a single query, so the scan cannot move forward.
+var ErrScanStalled = errors.New("document scan stalled: too many documents share the same value of the scanned attribute")
+
+// ScanAttributeError is returned by the iterator of ScanDocuments when the
+// scanned attribute is not filterable and sortable, before any search, or
+// when its first value is not a number, as with string primary keys
+type ScanAttributeError struct {
+	IndexUID      string
+	Attribute     string
+	NotFilterable bool
+	NotSortable   bool
+	NotNumeric    bool
+}
+
+func (e *ScanAttributeError) Error() string {
+	var problems []string
+	if e.NotFilterable {
+		problems = append(problems, "not filterable")
+	}
+	if e.NotSortable {
+		problems = append(problems, "not sortable")
+	}
+	if e.NotNumeric {
+		problems = append(problems, "not numeric")
+	}
+	return fmt.Sprintf("cannot scan index %q by attribute %q: it is %s", e.IndexUID, e.Attribute, strings.Join(problems, " and "))
+}
+
+// ScanOptions configure ScanDocuments
+type ScanOptions struct {
+
+	// Attribute is the numeric attribute the documents are scanned by, it
+	// must be sortable and filterable. Default to the primary key.
+	Attribute string
+
+	// PrimaryKey is optional, it is fetched from the index when empty
+	PrimaryKey string
+
+	// PageSize is the number of documents fetched per search.
+	// Default to DefaultSearchPageSize.
+	PageSize int64
+
+	// AttributesToRetrieve is optional, the primary key and Attribute are
+	// always retrieved
+	AttributesToRetrieve []string
+}
+
+// ScanDocuments returns an iterator over every document matching filter,
+// which may be nil, without the limit Meilisearch puts on the number of hits
+// of a query.
+//
+// Documents are fetched with placeholder searches sorted by opts.Attribute,
+// every search starting where the previous one ended with an
+// "attribute >= last value" filter. The iterator fails with a
+// *ScanAttributeError when the attribute is not filterable and sortable or
+// when the first document has no numeric value for it, use SearchAll to read
+// indexes with string primary keys. Other documents without a numeric value
+// for the attribute are not returned. Documents added, updated or deleted
+// during the scan may be missed.
+func (i Index) ScanDocuments(ctx context.Context, filter interface{}, opts *ScanOptions) *SearchIterator {
+	o := ScanOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.PageSize <= 0 {
+		o.PageSize = DefaultSearchPageSize
+	}
+
+	it := &SearchIterator{ctx: ctx, index: i}
+
+	var (
+		started bool
+		last    interface{}
+		// Primary keys of the documents already read whose attribute is last
+		boundary = map[string]bool{}
+	)
+	it.fetchPage = func() (_ []json.RawMessage, _ bool, err error) {
+		if !started {
+			if err := o.resolve(i); err != nil {
+				return nil, true, err
+			}
+			started = true
+		}
+
+		request := &SearchRequest{
+			PlaceholderSearch:    true,
+			Limit:                o.PageSize,
+			Filter:               filter,
+			Sort:                 []string{o.Attribute + ":asc"},
+			AttributesToRetrieve: o.AttributesToRetrieve,
+		}
+		if last != nil {
+			if request.Filter, err = andFilter(filter, scanFilter(o.Attribute, last)); err != nil {
+				return nil, true, err
+			}
+			// Documents sharing the last value come first, skip those already read
+			request.Offset = int64(len(boundary))
+		}
+		resp, hits, err := i.SearchRaw("", request)
+		if err != nil {
+			return nil, true, err
+		}
+		it.resp = resp
+		if int64(len(hits)) < o.PageSize && request.Offset+int64(len(hits)) < resp.NbHits {
+			// Meilisearch does not return the documents past its limit of hits
+			return nil, true, ErrScanStalled
+		}
+		if len(hits) == 0 {
+			return nil, true, nil
+		}
+
+		page := make([]json.RawMessage, 0, len(hits))
+		for _, hit := range hits {
+			document, err := decodeDocument(i.client.jsonCodec(), hit)
+			if err != nil {
+				return nil, true, err
+			}
+			id, ok := documentID(document, o.PrimaryKey)
+			if !ok {
+				return nil, true, fmt.Errorf("hit without primary key %q", o.PrimaryKey)
+			}
+			value, ok := documentNumber(document[o.Attribute])
+			if !ok {
+				if last == nil {
+					// Numbers are sorted first, the attribute has none
+					return nil, true, &ScanAttributeError{IndexUID: i.UID, Attribute: o.Attribute, NotNumeric: true}
+				}
+				// Sorted after every numeric value, nothing left to scan
+				return page, true, nil
+			}
+			if last == nil || value != last {
+				last = value
+				boundary = map[string]bool{}
+			}
+			if boundary[id] {
+				continue
+			}
+			boundary[id] = true
+			page = append(page, hit)
+		}
+		if len(page) == 0 {
+			return nil, true, ErrScanStalled
+		}
+		return page, int64(len(hits)) < o.PageSize, nil
+	}
+	return it
+}
+
+// resolve fills the primary key and the scanned attribute
+func (o *ScanOptions) resolve(i Index) error {
+	if o.PrimaryKey == "" {
+		primaryKey, err := i.FetchPrimaryKey()
+		if err != nil {
+			return err
+		}
+		if *primaryKey == "" {
+			return fmt.Errorf("index %q has no primary key", i.UID)
+		}
+		o.PrimaryKey = *primaryKey
+	}
+	if o.Attribute == "" {
+		o.Attribute = o.PrimaryKey
+	}
+
+	// Meilisearch rejects the sort or the filter of the scan otherwise
+	attributeErr := &ScanAttributeError{IndexUID: i.UID, Attribute: o.Attribute}
+	filterable, err := i.GetFilterableAttributes()
+	if err != nil {
+		return err
+	}
+	attributeErr.NotFilterable = !containsString(*filterable, o.Attribute)
+	sortable, err := i.GetSortableAttributes()
+	if err != nil {
+		return err
+	}
+	attributeErr.NotSortable = !containsString(*sortable, o.Attribute)
+	if attributeErr.NotFilterable || attributeErr.NotSortable {
+		return attributeErr
+	}
+
+	if len(o.AttributesToRetrieve) != 0 {
+		o.AttributesToRetrieve = append(copyStrings(o.AttributesToRetrieve), o.PrimaryKey, o.Attribute)
+	}
+	return nil
+}
+
+func scanFilter(attribute string, last interface{}) string {
+	return filter.Gte(attribute, last).String()
+}
+
+// andFilter adds condition to a filter given as a string, an array or a
+// filter.Expression
+func andFilter(f interface{}, condition string) (interface{}, error) {
+	switch v := f.(type) {
+	case nil:
+		return condition, nil
+	case string:
+		if v == "" {
+			return condition, nil
+		}
+		return "(" + v + ") AND " + condition, nil
+	case []string:
+		array := make([]interface{}, 0, len(v)+1)
+		for _, s := range v {
+			array = append(array, s)
+		}
+		return append(array, condition), nil
+	case []interface{}:
+		return append(append([]interface{}{}, v...), condition), nil
+	case filter.Expression:
+		if err := filter.Validate(v); err != nil {
+			return nil, err
+		}
+		return andFilter(v.String(), condition)
+	case fmt.Stringer:
+		return andFilter(v.String(), condition)
+	default:
+		return nil, fmt.Errorf("unsupported filter type %T", f)
+	}
+}
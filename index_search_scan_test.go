@@ -0,0 +1,193 @@
+package meilisearch
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/meilisearch/meilisearch-go/filter"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_andFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:   "TestAndFilterNil",
+			filter: nil,
+			want:   "year >= 1865",
+		},
+		{
+			name:   "TestAndFilterEmptyString",
+			filter: "",
+			want:   "year >= 1865",
+		},
+		{
+			name:   "TestAndFilterString",
+			filter: "tag = Novel OR tag = Tale",
+			want:   "(tag = Novel OR tag = Tale) AND year >= 1865",
+		},
+		{
+			name:   "TestAndFilterStringArray",
+			filter: []string{"tag = Novel"},
+			want:   []interface{}{"tag = Novel", "year >= 1865"},
+		},
+		{
+			name:   "TestAndFilterArray",
+			filter: []interface{}{[]string{"tag = Novel", "tag = Tale"}},
+			want:   []interface{}{[]string{"tag = Novel", "tag = Tale"}, "year >= 1865"},
+		},
+		{
+			name:   "TestAndFilterExpression",
+			filter: filter.Eq("tag", "Novel"),
+			want:   "(tag = \"Novel\") AND year >= 1865",
+		},
+		{
+			name:    "TestAndFilterUnsupported",
+			filter:  42,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := andFilter(tt.filter, scanFilter("year", json.Number("1865")))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIndex_ScanDocuments(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  interface{}
+		opts    *ScanOptions
+		wantIDs int
+	}{
+		{
+			name:    "TestIndexScanDocumentsByPrimaryKey",
+			opts:    &ScanOptions{PageSize: 3},
+			wantIDs: 20,
+		},
+		{
+			name:    "TestIndexScanDocumentsByAttributeWithDuplicates",
+			opts:    &ScanOptions{Attribute: "year", PageSize: 3},
+			wantIDs: 20,
+		},
+		{
+			name:    "TestIndexScanDocumentsWithFilter",
+			filter:  "tag = Novel",
+			opts:    &ScanOptions{Attribute: "year", PageSize: 2},
+			wantIDs: 5,
+		},
+		{
+			name:    "TestIndexScanDocumentsWithFilterExpression",
+			filter:  filter.Eq("tag", "Novel"),
+			opts:    &ScanOptions{PageSize: 2, AttributesToRetrieve: []string{"title"}},
+			wantIDs: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetUpIndexForFaceting()
+			c := defaultClient
+			i := c.Index("indexUID")
+			t.Cleanup(cleanup(c))
+
+			update, err := i.UpdateFilterableAttributes(&[]string{"book_id", "tag", "year"})
+			require.NoError(t, err)
+			testWaitForPendingUpdate(t, i, update)
+			update, err = i.UpdateSortableAttributes(&[]string{"book_id", "year"})
+			require.NoError(t, err)
+			testWaitForPendingUpdate(t, i, update)
+
+			it := i.ScanDocuments(context.Background(), tt.filter, tt.opts)
+			seen := map[int]bool{}
+			for it.Next() {
+				var book docTestBooks
+				require.NoError(t, it.Scan(&book))
+				require.False(t, seen[book.BookID], "document %d returned twice", book.BookID)
+				seen[book.BookID] = true
+			}
+			require.NoError(t, it.Err())
+			require.Len(t, seen, tt.wantIDs)
+		})
+	}
+}
+
+func TestIndex_ScanDocumentsPastPaginationCap(t *testing.T) {
+	c := defaultClient
+	i := c.Index("scandocuments")
+	t.Cleanup(cleanup(c))
+
+	documents := make([]map[string]interface{}, 1005)
+	for j := range documents {
+		documents[j] = map[string]interface{}{"id": j, "group": j / 1002}
+	}
+	update, err := i.AddDocuments(documents, "id")
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+	update, err = i.UpdateFilterableAttributes(&[]string{"id", "group"})
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+	update, err = i.UpdateSortableAttributes(&[]string{"id", "group"})
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+
+	it := i.ScanDocuments(context.Background(), nil, &ScanOptions{PageSize: 400})
+	documentsRead := 0
+	for it.Next() {
+		documentsRead++
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, 1005, documentsRead)
+
+	// 1002 documents share group 0, more than a search returns
+	it = i.ScanDocuments(context.Background(), nil, &ScanOptions{Attribute: "group", PageSize: 400})
+	for it.Next() {
+	}
+	require.Equal(t, ErrScanStalled, it.Err())
+}
+
+func TestIndex_ScanDocumentsAttributeErrors(t *testing.T) {
+	c := defaultClient
+	i := c.Index("scandocumentsstrings")
+	t.Cleanup(cleanup(c))
+
+	documents := []map[string]interface{}{
+		{"id": "b", "year": 1813},
+		{"id": "a", "year": 1943},
+	}
+	update, err := i.AddDocuments(documents, "id")
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+
+	// Not filterable nor sortable, nothing is searched
+	it := i.ScanDocuments(context.Background(), nil, nil)
+	require.False(t, it.Next())
+	require.Equal(t, &ScanAttributeError{IndexUID: "scandocumentsstrings", Attribute: "id", NotFilterable: true, NotSortable: true}, it.Err())
+
+	update, err = i.UpdateFilterableAttributes(&[]string{"id", "year"})
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+	update, err = i.UpdateSortableAttributes(&[]string{"id"})
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+
+	it = i.ScanDocuments(context.Background(), nil, &ScanOptions{Attribute: "year"})
+	require.False(t, it.Next())
+	require.Equal(t, &ScanAttributeError{IndexUID: "scandocumentsstrings", Attribute: "year", NotSortable: true}, it.Err())
+
+	// A string primary key is not silently scanned as empty
+	it = i.ScanDocuments(context.Background(), nil, nil)
+	require.False(t, it.Next())
+	require.Equal(t, &ScanAttributeError{IndexUID: "scandocumentsstrings", Attribute: "id", NotNumeric: true}, it.Err())
+}
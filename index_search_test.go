@@ -3,6 +3,7 @@ package meilisearch
 import (
 	"testing"
 
+	"github.com/meilisearch/meilisearch-go/filter"
 	"github.com/stretchr/testify/require"
 )
 
@@ -293,14 +294,13 @@ func TestIndex_SearchFacets(t *testing.T) {
 				Offset:           0,
 				Limit:            20,
 				ExhaustiveNbHits: false,
-				FacetsDistribution: map[string]interface{}(
-					map[string]interface{}{
-						"tag": map[string]interface{}{
-							"Epic fantasy": float64(1),
-							"Tale":         float64(1),
-						},
-					}),
-				ExhaustiveFacetsCount: interface{}(false),
+				FacetsDistribution: map[string]map[string]int64{
+					"tag": {
+						"Epic fantasy": 1,
+						"Tale":         1,
+					},
+				},
+				ExhaustiveFacetsCount: false,
 			},
 		},
 		{
@@ -327,14 +327,13 @@ func TestIndex_SearchFacets(t *testing.T) {
 				Offset:           0,
 				Limit:            20,
 				ExhaustiveNbHits: false,
-				FacetsDistribution: map[string]interface{}(
-					map[string]interface{}{
-						"tag": map[string]interface{}{
-							"Epic fantasy": float64(1),
-							"Tale":         float64(1),
-						},
-					}),
-				ExhaustiveFacetsCount: interface{}(false),
+				FacetsDistribution: map[string]map[string]int64{
+					"tag": {
+						"Epic fantasy": 1,
+						"Tale":         1,
+					},
+				},
+				ExhaustiveFacetsCount: false,
 			},
 		},
 	}
@@ -656,6 +655,56 @@ func TestIndex_SearchWithFilters(t *testing.T) {
 				ExhaustiveNbHits: false,
 			},
 		},
+		{
+			name: "TestIndexSearchWithFilterExpression",
+			args: args{
+				UID:    "indexUID",
+				client: defaultClient,
+				query:  "and",
+				filterableAttributes: []string{
+					"tag", "year",
+				},
+				request: SearchRequest{
+					Filter: filter.And(filter.Eq("tag", "Crime fiction"), filter.Gt("year", 1800)),
+				},
+			},
+			want: &SearchResponse{
+				Hits: []interface{}{
+					map[string]interface{}{
+						"book_id": float64(1032), "title": "Crime and Punishment",
+					},
+				},
+				NbHits:           1,
+				Offset:           0,
+				Limit:            20,
+				ExhaustiveNbHits: false,
+			},
+		},
+		{
+			name: "TestIndexSearchWithFilterExpressionArray",
+			args: args{
+				UID:    "indexUID",
+				client: defaultClient,
+				query:  "and",
+				filterableAttributes: []string{
+					"tag", "year",
+				},
+				request: SearchRequest{
+					Filter: filter.Array(filter.And(filter.In("tag", "Romance", "Tragedy"), filter.Lt("year", 1900))),
+				},
+			},
+			want: &SearchResponse{
+				Hits: []interface{}{
+					map[string]interface{}{
+						"book_id": float64(123), "title": "Pride and Prejudice",
+					},
+				},
+				NbHits:           1,
+				Offset:           0,
+				Limit:            20,
+				ExhaustiveNbHits: false,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -916,3 +965,50 @@ func TestIndex_SearchWithSort(t *testing.T) {
 		})
 	}
 }
+
+func Test_searchIntoResponse(t *testing.T) {
+	var hits []docTestBooks
+	resp := &searchIntoResponse{Hits: &hits}
+	err := DefaultJSONCodec().Unmarshal([]byte(`{
+		"hits": [{"book_id": 456, "title": "Le Petit Prince", "tag": "Tale", "year": 1943}],
+		"nbHits": 1, "offset": 0, "limit": 20, "processingTimeMs": 2, "query": "prince",
+		"facetsDistribution": {"tag": {"Tale": 1}}
+	}`), resp)
+	require.NoError(t, err)
+	require.Equal(t, []docTestBooks{{BookID: 456, Title: "Le Petit Prince", Tag: "Tale", Year: 1943}}, hits)
+	require.Equal(t, &SearchResponse{
+		NbHits:             1,
+		Limit:              20,
+		ProcessingTimeMs:   2,
+		Query:              "prince",
+		FacetsDistribution: map[string]map[string]int64{"tag": {"Tale": 1}},
+	}, resp.searchResponse())
+}
+
+func TestIndex_SearchInto(t *testing.T) {
+	SetUpIndexForFaceting()
+	c := defaultClient
+	i := c.Index("indexUID")
+	t.Cleanup(cleanup(c))
+
+	var hits []docTestBooks
+	got, err := i.SearchInto("prince", &SearchRequest{}, &hits)
+	require.NoError(t, err)
+	require.Nil(t, got.Hits)
+	require.Equal(t, int64(2), got.NbHits)
+	require.Equal(t, int64(20), got.Limit)
+	require.Equal(t, "prince", got.Query)
+	require.ElementsMatch(t, []docTestBooks{
+		{BookID: 456, Title: "Le Petit Prince", Tag: "Tale", Year: 1943},
+		{BookID: 4, Title: "Harry Potter and the Half-Blood Prince", Tag: "Epic fantasy", Year: 2005},
+	}, hits)
+
+	got, raw, err := i.SearchRaw("prince", &SearchRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), got.NbHits)
+	require.Len(t, raw, 1)
+	require.Contains(t, string(raw[0]), "Prince")
+
+	_, err = i.SearchInto("prince", &SearchRequest{}, hits)
+	require.Error(t, err)
+}
@@ -0,0 +1,262 @@
+package meilisearch
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// settingsTag is the struct tag read by SettingsFor
+//
+// Example:
+//
+//	type Book struct {
+//		ID    int      `json:"id" meili:"displayed"`
+//		Title string   `json:"title" meili:"searchable,displayed,order=1"`
+//		Tags  []string `json:"tags" meili:"searchable,filterable,displayed,order=2"`
+//		Year  int      `json:"year" meili:"filterable,sortable"`
+//		ISBN  string   `json:"isbn" meili:"distinct"`
+//	}
+const settingsTag = "meili"
+
+// SettingsFor derives the attribute settings of an index from the `meili`
+// struct tags of a document type. documentPtr is a struct, a pointer to a
+// struct or a slice of them.
+//
+// The supported tag options are searchable, filterable, sortable, displayed,
+// distinct and order=N. Searchable and displayed attributes are ordered by
+// their order option first then by declaration order. Attributes are named
+// after their json tag. When no field is searchable (or displayed) the
+// corresponding setting is left empty, meaning all attributes.
+func SettingsFor(documentPtr interface{}) (resp *Settings, err error) {
+	t := reflect.TypeOf(documentPtr)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("SettingsFor expects a struct, got %T", documentPtr)
+	}
+
+	fields, err := settingsFields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &Settings{}
+	var searchable, displayed []settingsField
+	for _, field := range fields {
+		if field.searchable {
+			searchable = append(searchable, field)
+		}
+		if field.displayed {
+			displayed = append(displayed, field)
+		}
+		if field.filterable {
+			resp.FilterableAttributes = append(resp.FilterableAttributes, field.name)
+		}
+		if field.sortable {
+			resp.SortableAttributes = append(resp.SortableAttributes, field.name)
+		}
+		if field.distinct {
+			if resp.DistinctAttribute != nil {
+				return nil, fmt.Errorf("only one distinct attribute is allowed, found %q and %q", *resp.DistinctAttribute, field.name)
+			}
+			name := field.name
+			resp.DistinctAttribute = &name
+		}
+	}
+	resp.SearchableAttributes = orderedSettingsFields(searchable)
+	resp.DisplayedAttributes = orderedSettingsFields(displayed)
+	return resp, nil
+}
+
+// EnsureSettingsFor applies the settings derived by SettingsFor from
+// documentPtr to the index. Only the searchable, displayed, filterable and
+// sortable attributes and the distinct attribute are managed, attributes not
+// set by the tags are reset. A nil update is returned when the index settings
+// are already up to date.
+func (i Index) EnsureSettingsFor(documentPtr interface{}) (resp *AsyncUpdateID, err error) {
+	wanted, err := SettingsFor(documentPtr)
+	if err != nil {
+		return nil, err
+	}
+	if wanted.SearchableAttributes == nil {
+		wanted.SearchableAttributes = []string{"*"}
+	}
+	if wanted.DisplayedAttributes == nil {
+		wanted.DisplayedAttributes = []string{"*"}
+	}
+
+	current, err := i.GetSettings()
+	if err != nil {
+		if apiErr, ok := err.(*Error); !ok || apiErr.MeilisearchApiError.Code != "index_not_found" {
+			return nil, err
+		}
+		current = &Settings{}
+	}
+	if equalStrings(current.SearchableAttributes, wanted.SearchableAttributes) &&
+		equalStrings(current.DisplayedAttributes, wanted.DisplayedAttributes) &&
+		equalStringSets(current.FilterableAttributes, wanted.FilterableAttributes) &&
+		equalStringSets(current.SortableAttributes, wanted.SortableAttributes) &&
+		reflect.DeepEqual(current.DistinctAttribute, wanted.DistinctAttribute) {
+		return nil, nil
+	}
+
+	// Settings omits empty values, a map is sent so that empty lists and a
+	// null distinct attribute reset the current values.
+	request := map[string]interface{}{
+		"searchableAttributes": wanted.SearchableAttributes,
+		"displayedAttributes":  wanted.DisplayedAttributes,
+		"filterableAttributes": nonNilStrings(wanted.FilterableAttributes),
+		"sortableAttributes":   nonNilStrings(wanted.SortableAttributes),
+		"distinctAttribute":    wanted.DistinctAttribute,
+	}
+	resp = &AsyncUpdateID{}
+	req := internalRequest{
+		endpoint:            "/indexes/" + i.UID + "/settings",
+		method:              http.MethodPost,
+		contentType:         contentTypeJSON,
+		withRequest:         request,
+		withResponse:        resp,
+		acceptedStatusCodes: []int{http.StatusAccepted},
+		functionName:        "EnsureSettingsFor",
+	}
+	if err := i.client.executeRequest(req); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type settingsField struct {
+	name       string
+	order      int
+	hasOrder   bool
+	searchable bool
+	filterable bool
+	sortable   bool
+	displayed  bool
+	distinct   bool
+}
+
+func settingsFields(t reflect.Type) ([]settingsField, error) {
+	var fields []settingsField
+	for j := 0; j < t.NumField(); j++ {
+		f := t.Field(j)
+		name := f.Name
+		if jsonTag, ok := f.Tag.Lookup("json"); ok {
+			jsonName := strings.Split(jsonTag, ",")[0]
+			if jsonName == "-" {
+				continue
+			}
+			if jsonName != "" {
+				name = jsonName
+			}
+		}
+
+		// Fields of embedded structs are promoted like encoding/json does
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && ft.Kind() == reflect.Struct && name == f.Name {
+			embedded, err := settingsFields(ft)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embedded...)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		tag, ok := f.Tag.Lookup(settingsTag)
+		if !ok || tag == "" || tag == "-" {
+			continue
+		}
+		field := settingsField{name: name}
+		for _, option := range strings.Split(tag, ",") {
+			option = strings.TrimSpace(option)
+			switch {
+			case option == "searchable":
+				field.searchable = true
+			case option == "filterable":
+				field.filterable = true
+			case option == "sortable":
+				field.sortable = true
+			case option == "displayed":
+				field.displayed = true
+			case option == "distinct":
+				field.distinct = true
+			case strings.HasPrefix(option, "order="):
+				order, err := strconv.Atoi(strings.TrimPrefix(option, "order="))
+				if err != nil {
+					return nil, fmt.Errorf("invalid order in %s tag of field %s: %q", settingsTag, f.Name, option)
+				}
+				field.order = order
+				field.hasOrder = true
+			default:
+				return nil, fmt.Errorf("unknown option in %s tag of field %s: %q", settingsTag, f.Name, option)
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// orderedSettingsFields returns the names of the fields with an order first,
+// sorted by order, then the others in declaration order.
+func orderedSettingsFields(fields []settingsField) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+	sort.SliceStable(fields, func(a, b int) bool {
+		if fields[a].hasOrder != fields[b].hasOrder {
+			return fields[a].hasOrder
+		}
+		return fields[a].order < fields[b].order
+	})
+	names := make([]string, len(fields))
+	for j, field := range fields {
+		names[j] = field.name
+	}
+	return names
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for j := range a {
+		if a[j] != b[j] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalStringSets(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]bool, len(a))
+	for _, s := range a {
+		set[s] = true
+	}
+	for _, s := range b {
+		if !set[s] {
+			return false
+		}
+	}
+	return true
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,139 @@
+package meilisearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type docTestTaggedBase struct {
+	ID int `json:"id" meili:"displayed,order=0"`
+}
+
+type docTestTagged struct {
+	docTestTaggedBase
+	Title    string   `json:"title" meili:"searchable,displayed,order=1"`
+	Tags     []string `json:"tags" meili:"searchable,filterable,displayed"`
+	Year     int      `json:"year" meili:"filterable,sortable"`
+	Author   string   `json:"author" meili:"searchable,displayed,order=2"`
+	ISBN     string   `json:"isbn" meili:"distinct"`
+	Internal string   `json:"-" meili:"searchable"`
+	Comment  string   `json:"comment"`
+}
+
+func TestSettingsFor(t *testing.T) {
+	isbn := "isbn"
+	tests := []struct {
+		name        string
+		documentPtr interface{}
+		want        *Settings
+		wantErr     bool
+	}{
+		{
+			name:        "TestSettingsForStruct",
+			documentPtr: docTestTagged{},
+			want: &Settings{
+				DistinctAttribute:    &isbn,
+				SearchableAttributes: []string{"title", "author", "tags"},
+				DisplayedAttributes:  []string{"id", "title", "author", "tags"},
+				FilterableAttributes: []string{"tags", "year"},
+				SortableAttributes:   []string{"year"},
+			},
+		},
+		{
+			name:        "TestSettingsForSliceOfPointers",
+			documentPtr: &[]*docTestTagged{},
+			want: &Settings{
+				DistinctAttribute:    &isbn,
+				SearchableAttributes: []string{"title", "author", "tags"},
+				DisplayedAttributes:  []string{"id", "title", "author", "tags"},
+				FilterableAttributes: []string{"tags", "year"},
+				SortableAttributes:   []string{"year"},
+			},
+		},
+		{
+			name:        "TestSettingsForUntaggedStruct",
+			documentPtr: docTestBooks{},
+			want:        &Settings{},
+		},
+		{
+			name: "TestSettingsForUnknownOption",
+			documentPtr: struct {
+				Title string `meili:"searchable,typo"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "TestSettingsForTwoDistinctAttributes",
+			documentPtr: struct {
+				A string `meili:"distinct"`
+				B string `meili:"distinct"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name:        "TestSettingsForNotAStruct",
+			documentPtr: map[string]interface{}{},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResp, err := SettingsFor(tt.documentPtr)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, gotResp)
+		})
+	}
+}
+
+func TestIndex_EnsureSettingsFor(t *testing.T) {
+	type args struct {
+		UID    string
+		client *Client
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "TestIndexEnsureSettingsFor",
+			args: args{
+				UID:    "indexUID",
+				client: defaultClient,
+			},
+		},
+		{
+			name: "TestIndexEnsureSettingsForWithCustomClient",
+			args: args{
+				UID:    "indexUID",
+				client: customClient,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.args.client
+			i := c.Index(tt.args.UID)
+			t.Cleanup(cleanup(c))
+			SetUpBasicIndex()
+
+			gotResp, err := i.EnsureSettingsFor(docTestTagged{})
+			require.NoError(t, err)
+			require.NotNil(t, gotResp)
+			testWaitForPendingUpdate(t, i, gotResp)
+
+			settings, err := i.GetSettings()
+			require.NoError(t, err)
+			require.Equal(t, []string{"title", "author", "tags"}, settings.SearchableAttributes)
+			require.ElementsMatch(t, []string{"tags", "year"}, settings.FilterableAttributes)
+
+			gotResp, err = i.EnsureSettingsFor(docTestTagged{})
+			require.NoError(t, err)
+			require.Nil(t, gotResp)
+		})
+	}
+}
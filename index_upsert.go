@@ -0,0 +1,434 @@
+package meilisearch
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultContentHashField is a suggested UpsertOptions.HashField, the document
+// field where UpsertChangedDocuments stores the content hash of each document.
+const DefaultContentHashField = "_contentHash"
+
+// DocumentHashStore keeps the content hash of the documents sent by
+// UpsertChangedDocuments, by primary key value.
+type DocumentHashStore interface {
+	Load(ctx context.Context) (map[string]string, error)
+	Save(ctx context.Context, hashes map[string]string) error
+}
+
+// FileHashStore is a DocumentHashStore persisted as a JSON object in a local file
+type FileHashStore struct {
+	Path string
+
+	// JSONCodec encodes the file. Default to DefaultJSONCodec.
+	JSONCodec JSONCodec
+}
+
+// Load returns the hashes of the file, a missing file is an empty store
+func (s FileHashStore) Load(ctx context.Context) (map[string]string, error) {
+	hashes := map[string]string{}
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return hashes, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read hash store")
+	}
+	if err := codecOrDefault(s.JSONCodec).Unmarshal(data, &hashes); err != nil {
+		return nil, errors.Wrap(err, "could not decode hash store")
+	}
+	return hashes, nil
+}
+
+// Save replaces the content of the file with the hashes
+func (s FileHashStore) Save(ctx context.Context, hashes map[string]string) error {
+	data, err := codecOrDefault(s.JSONCodec).Marshal(hashes)
+	if err != nil {
+		return errors.Wrap(err, "could not encode hash store")
+	}
+	// Write to a temporary file first so a crash never leaves a truncated store
+	if err := ioutil.WriteFile(s.Path+".tmp", data, 0o644); err != nil {
+		return errors.Wrap(err, "could not write hash store")
+	}
+	return errors.Wrap(os.Rename(s.Path+".tmp", s.Path), "could not write hash store")
+}
+
+// UpsertOptions configure UpsertChangedDocuments
+type UpsertOptions struct {
+
+	// PrimaryKey of the documents. Default to the primary key of the index.
+	PrimaryKey string
+
+	// BatchSize is the number of documents sent at a time. Default to 1000.
+	BatchSize int
+
+	// Store keeps the hashes outside of the index. Either Store or HashField
+	// is required.
+	Store DocumentHashStore
+
+	// HashField, used when Store is nil, stores the hashes in this field of
+	// every document and reads them back from the index. It changes the
+	// searchable attributes of the index, see UpsertChangedDocuments.
+	HashField string
+
+	// DeleteMissing deletes the known documents that are not part of the input
+	DeleteMissing bool
+}
+
+// UpsertResult is returned by UpsertChangedDocuments
+type UpsertResult struct {
+	Added     int
+	Changed   int
+	Unchanged int
+	Deleted   int
+	Updates   []AsyncUpdateID
+}
+
+// UpsertChangedDocuments sends through UpdateDocuments only the documents
+// that were added or changed since the previous run.
+//
+// Every document is hashed and compared with the hash known for its primary
+// key, either read from the index or from opts.Store. With opts.DeleteMissing
+// the known documents absent from documentsPtr are deleted.
+// When a Store is used it is saved only once every update has been processed,
+// so a failed run is retried in full on the next one.
+//
+// With opts.HashField, the hashes must not be searchable: the first time they
+// are stored the field is removed from the searchable attributes of the
+// index. When every attribute is searchable ("*") they are replaced by the
+// list of the fields of the documents and of the index at that time, so
+// fields added to the documents later are not searchable until they are
+// added to the searchable attributes. Use a Store to leave the settings
+// untouched.
+func (i Index) UpsertChangedDocuments(ctx context.Context, documentsPtr interface{}, opts *UpsertOptions) (resp *UpsertResult, err error) {
+	if opts == nil {
+		opts = &UpsertOptions{}
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	hashField := opts.HashField
+	if opts.Store == nil && hashField == "" {
+		return nil, fmt.Errorf("a Store or a HashField is required to upsert documents in index %q", i.UID)
+	}
+
+	primaryKey := opts.PrimaryKey
+	if primaryKey == "" {
+		indexPrimaryKey, err := i.FetchPrimaryKey()
+		if err != nil {
+			return nil, err
+		}
+		primaryKey = *indexPrimaryKey
+	}
+	if primaryKey == "" {
+		return nil, fmt.Errorf("a primary key is required to upsert documents in index %q", i.UID)
+	}
+
+	documents, err := normalizeDocuments(i.client.jsonCodec(), documentsPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	var known map[string]string
+	if opts.Store != nil {
+		known, err = opts.Store.Load(ctx)
+	} else {
+		known, err = i.loadContentHashes(ctx, primaryKey, hashField)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &UpsertResult{}
+	hashes := make(map[string]string, len(documents))
+	var changed []map[string]interface{}
+	for j, document := range documents {
+		id, ok := documentID(document, primaryKey)
+		if !ok {
+			return nil, fmt.Errorf("document %d has no valid %q primary key", j, primaryKey)
+		}
+		delete(document, hashField)
+		hash, err := contentHash(document)
+		if err != nil {
+			return nil, err
+		}
+		hashes[id] = hash
+
+		previous, ok := known[id]
+		switch {
+		case !ok:
+			resp.Added++
+		case previous != hash:
+			resp.Changed++
+		default:
+			resp.Unchanged++
+			continue
+		}
+		if opts.Store == nil {
+			document[hashField] = hash
+		}
+		changed = append(changed, document)
+	}
+
+	if len(changed) != 0 {
+		if opts.Store == nil && !hasContentHashes(known) {
+			// First hashes stored in the index, they must not be searchable.
+			// Updates are processed in order, the setting applies to the documents.
+			update, err := i.excludeFromSearchableAttributes(hashField, documentsPtr, documents)
+			if err != nil {
+				return nil, err
+			}
+			if update != nil {
+				resp.Updates = append(resp.Updates, *update)
+			}
+		}
+		updates, err := i.UpdateDocumentsInBatches(changed, batchSize, primaryKey)
+		if err != nil {
+			return nil, err
+		}
+		resp.Updates = append(resp.Updates, updates...)
+	}
+
+	if opts.DeleteMissing {
+		var missing []string
+		for id := range known {
+			if _, ok := hashes[id]; !ok {
+				missing = append(missing, id)
+			}
+		}
+		for start := 0; start < len(missing); start += batchSize {
+			end := start + batchSize
+			if end > len(missing) {
+				end = len(missing)
+			}
+			update, err := i.DeleteDocuments(missing[start:end])
+			if err != nil {
+				return resp, err
+			}
+			resp.Updates = append(resp.Updates, *update)
+		}
+		resp.Deleted = len(missing)
+	} else {
+		for id, hash := range known {
+			if _, ok := hashes[id]; !ok {
+				hashes[id] = hash
+			}
+		}
+	}
+
+	if opts.Store != nil {
+		if err := i.waitForUpdates(ctx, time.Millisecond*50, resp.Updates); err != nil {
+			return resp, err
+		}
+		if err := opts.Store.Save(ctx, hashes); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+// loadContentHashes reads the hash field of every document of the index
+func (i Index) loadContentHashes(ctx context.Context, primaryKey string, hashField string) (map[string]string, error) {
+	hashes := map[string]string{}
+	err := i.forEachDocumentsPage(ctx, 1000, []string{primaryKey, hashField}, func(page []json.RawMessage) error {
+		for _, raw := range page {
+			document, err := decodeDocument(i.client.jsonCodec(), raw)
+			if err != nil {
+				return err
+			}
+			id, ok := documentID(document, primaryKey)
+			if !ok {
+				continue
+			}
+			hash, _ := document[hashField].(string)
+			hashes[id] = hash
+		}
+		return nil
+	})
+	if apiErr, ok := err.(*Error); ok && apiErr.MeilisearchApiError.Code == "index_not_found" {
+		return hashes, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+func hasContentHashes(known map[string]string) bool {
+	for _, hash := range known {
+		if hash != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// excludeFromSearchableAttributes removes field from the searchable attributes
+// of the index. When every attribute is searchable the other fields are listed
+// explicitly: the fields of the first document in its order, then the other
+// fields of the documents and of the index. It returns nil when the field is
+// already not searchable.
+func (i Index) excludeFromSearchableAttributes(field string, documentsPtr interface{}, documents []map[string]interface{}) (*AsyncUpdateID, error) {
+	searchable, err := i.GetSearchableAttributes()
+	if apiErr, ok := err.(*Error); ok && apiErr.MeilisearchApiError.Code == "index_not_found" {
+		searchable, err = &[]string{"*"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := *searchable
+	if len(attributes) == 1 && attributes[0] == "*" {
+		if attributes, err = i.documentFields(documentsPtr, documents); err != nil {
+			return nil, err
+		}
+	} else if !containsString(attributes, field) {
+		return nil, nil
+	}
+
+	kept := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		if attribute != field {
+			kept = append(kept, attribute)
+		}
+	}
+	return i.UpdateSearchableAttributes(&kept)
+}
+
+// documentFields returns the fields of the documents and of the index, see
+// excludeFromSearchableAttributes
+func (i Index) documentFields(documentsPtr interface{}, documents []map[string]interface{}) ([]string, error) {
+	fields, err := firstDocumentFields(i.client.jsonCodec(), documentsPtr)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, field := range fields {
+		seen[field] = true
+	}
+
+	var others []string
+	for _, document := range documents {
+		for field := range document {
+			if !seen[field] {
+				seen[field] = true
+				others = append(others, field)
+			}
+		}
+	}
+	stats, err := i.GetStats()
+	if apiErr, ok := err.(*Error); !ok || apiErr.MeilisearchApiError.Code != "index_not_found" {
+		if err != nil {
+			return nil, err
+		}
+		for field := range stats.FieldDistribution {
+			if !seen[field] {
+				seen[field] = true
+				others = append(others, field)
+			}
+		}
+	}
+	sort.Strings(others)
+	return append(fields, others...), nil
+}
+
+// firstDocumentFields returns the fields of the first document in the order
+// they are encoded
+func firstDocumentFields(codec JSONCodec, documentsPtr interface{}) ([]string, error) {
+	data, err := codec.Marshal(documentsPtr)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode documents")
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	if _, err := d.Token(); err != nil {
+		return nil, errors.Wrap(err, "documents must be an array of objects")
+	}
+	if !d.More() {
+		return nil, nil
+	}
+	if _, err := d.Token(); err != nil {
+		return nil, errors.Wrap(err, "documents must be an array of objects")
+	}
+	var fields []string
+	for d.More() {
+		token, err := d.Token()
+		if err != nil {
+			return nil, errors.Wrap(err, "documents must be an array of objects")
+		}
+		field, _ := token.(string)
+		fields = append(fields, field)
+		var value json.RawMessage
+		if err := d.Decode(&value); err != nil {
+			return nil, errors.Wrap(err, "documents must be an array of objects")
+		}
+	}
+	return fields, nil
+}
+
+// normalizeDocuments converts any slice of documents to generic JSON objects,
+// numbers are kept as json.Number, when the codec supports it, so they are
+// sent back unchanged.
+func normalizeDocuments(codec JSONCodec, documentsPtr interface{}) ([]map[string]interface{}, error) {
+	data, err := codec.Marshal(documentsPtr)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode documents")
+	}
+	var documents []map[string]interface{}
+	if err := unmarshalWithNumbers(codec, data, &documents); err != nil {
+		return nil, errors.Wrap(err, "documents must be an array of objects")
+	}
+	return documents, nil
+}
+
+func decodeDocument(codec JSONCodec, raw []byte) (map[string]interface{}, error) {
+	var document map[string]interface{}
+	if err := unmarshalWithNumbers(codec, raw, &document); err != nil {
+		return nil, errors.Wrap(err, "could not decode document")
+	}
+	return document, nil
+}
+
+// documentID returns the primary key value of a normalized document
+func documentID(document map[string]interface{}, primaryKey string) (string, bool) {
+	if id, ok := document[primaryKey].(string); ok {
+		return id, true
+	}
+	id, ok := documentNumber(document[primaryKey])
+	return id.String(), ok
+}
+
+// documentNumber returns a number of a normalized document, float64 when the
+// codec does not keep numbers as json.Number
+func documentNumber(value interface{}) (json.Number, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		return v, true
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'f', -1, 64)), true
+	default:
+		return "", false
+	}
+}
+
+// contentHash hashes the JSON encoding of a document, maps are encoded with
+// sorted keys so the hash does not depend on the field order.
+func contentHash(document map[string]interface{}) (string, error) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return "", errors.Wrap(err, "could not encode document")
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,127 @@
+package meilisearch
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_UpsertChangedDocuments(t *testing.T) {
+	type args struct {
+		UID    string
+		client *Client
+		opts   *UpsertOptions
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "TestIndexUpsertChangedDocumentsWithHashField",
+			args: args{
+				UID:    "TestIndexUpsertChangedDocumentsWithHashField",
+				client: defaultClient,
+				opts: &UpsertOptions{
+					PrimaryKey:    "book_id",
+					HashField:     DefaultContentHashField,
+					DeleteMissing: true,
+				},
+			},
+		},
+		{
+			name: "TestIndexUpsertChangedDocumentsWithFileHashStore",
+			args: args{
+				UID:    "TestIndexUpsertChangedDocumentsWithFileHashStore",
+				client: customClient,
+				opts: &UpsertOptions{
+					PrimaryKey:    "book_id",
+					BatchSize:     2,
+					Store:         FileHashStore{Path: filepath.Join(t.TempDir(), "hashes.json")},
+					DeleteMissing: true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.args.client
+			i := c.Index(tt.args.UID)
+			t.Cleanup(cleanup(c))
+
+			documents := []docTestBooks{
+				{BookID: 123, Title: "Pride and Prejudice", Tag: "Romance", Year: 1813},
+				{BookID: 456, Title: "Le Petit Prince", Tag: "Tale", Year: 1943},
+				{BookID: 1, Title: "Alice In Wonderland", Tag: "Tale", Year: 1865},
+			}
+			gotResp, err := i.UpsertChangedDocuments(context.Background(), documents, tt.args.opts)
+			require.NoError(t, err)
+			require.Equal(t, 3, gotResp.Added)
+			testWaitForPendingBatchUpdate(t, i, gotResp.Updates)
+
+			searchable, err := i.GetSearchableAttributes()
+			require.NoError(t, err)
+			if tt.args.opts.Store == nil {
+				require.Equal(t, &[]string{"book_id", "title", "tag", "year"}, searchable)
+			} else {
+				require.Equal(t, &[]string{"*"}, searchable)
+			}
+
+			gotResp, err = i.UpsertChangedDocuments(context.Background(), documents, tt.args.opts)
+			require.NoError(t, err)
+			require.Equal(t, &UpsertResult{Unchanged: 3}, gotResp)
+
+			documents = []docTestBooks{
+				{BookID: 123, Title: "Pride and Prejudice", Tag: "Romance", Year: 1813},
+				{BookID: 456, Title: "The Little Prince", Tag: "Tale", Year: 1943},
+				{BookID: 4, Title: "Harry Potter and the Half-Blood Prince", Tag: "Epic fantasy", Year: 2005},
+			}
+			gotResp, err = i.UpsertChangedDocuments(context.Background(), documents, tt.args.opts)
+			require.NoError(t, err)
+			require.Equal(t, 1, gotResp.Added)
+			require.Equal(t, 1, gotResp.Changed)
+			require.Equal(t, 1, gotResp.Unchanged)
+			require.Equal(t, 1, gotResp.Deleted)
+			testWaitForPendingBatchUpdate(t, i, gotResp.Updates)
+
+			if tt.args.opts.Store == nil {
+				// The hashes are not searchable
+				var hashed map[string]interface{}
+				require.NoError(t, i.GetDocument("456", &hashed))
+				hash, ok := hashed[DefaultContentHashField].(string)
+				require.True(t, ok)
+				searchResp, err := i.Search(hash, &SearchRequest{})
+				require.NoError(t, err)
+				require.Zero(t, searchResp.NbHits)
+			}
+
+			var document docTestBooks
+			require.NoError(t, i.GetDocument("456", &document))
+			require.Equal(t, "The Little Prince", document.Title)
+			require.Error(t, i.GetDocument("1", &document))
+		})
+	}
+}
+
+func TestIndex_UpsertChangedDocumentsRequiresStoreOrHashField(t *testing.T) {
+	documents := []docTestBooks{{BookID: 123, Title: "Pride and Prejudice"}}
+	_, err := defaultClient.Index("upsert").UpsertChangedDocuments(context.Background(), documents, &UpsertOptions{PrimaryKey: "book_id"})
+	require.Error(t, err)
+	_, err = defaultClient.Index("upsert").UpsertChangedDocuments(context.Background(), documents, nil)
+	require.Error(t, err)
+}
+
+func Test_firstDocumentFields(t *testing.T) {
+	got, err := firstDocumentFields(DefaultJSONCodec(), []docTestBooks{{BookID: 1}, {BookID: 2}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"book_id", "title", "tag", "year"}, got)
+
+	got, err = firstDocumentFields(DefaultJSONCodec(), []map[string]interface{}{{"title": "Hamlet", "id": 1}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "title"}, got)
+
+	got, err = firstDocumentFields(DefaultJSONCodec(), []docTestBooks{})
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
@@ -0,0 +1,87 @@
+package meilisearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// JSONCodec encodes and decodes the JSON bodies of requests and responses,
+// documents and search hits included. It is set with ClientConfig.JSONCodec to
+// use a faster JSON library than encoding/json.
+//
+// A codec must behave as encoding/json: values implementing json.Marshaler or
+// json.Unmarshaler, like the easyjson types of this package, must be encoded
+// and decoded with their own methods.
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+	NewDecoder(r io.Reader) JSONDecoder
+}
+
+// JSONDecoder reads successive JSON values from a stream.
+//
+// When the decoder also implements Token() (json.Token, error) it is used to
+// read JSON arrays of documents, otherwise encoding/json is used for them.
+// When it implements UseNumber() it is called before decoding documents whose
+// numbers must be kept exact, like primary keys above 2^53.
+type JSONDecoder interface {
+	Decode(v interface{}) error
+	More() bool
+}
+
+// DefaultJSONCodec returns the JSONCodec used when ClientConfig.JSONCodec is
+// not set, it is based on encoding/json.
+func DefaultJSONCodec() JSONCodec {
+	return stdJSONCodec{}
+}
+
+type stdJSONCodec struct{}
+
+// Marshal calls MarshalJSON directly on json.Marshaler values, skipping the
+// validation and compaction of its output done by json.Marshal
+func (stdJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	if marshaler, ok := v.(json.Marshaler); ok {
+		return marshaler.MarshalJSON()
+	}
+	return json.Marshal(v)
+}
+
+// Unmarshal calls UnmarshalJSON directly on json.Unmarshaler values
+func (stdJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	if unmarshaler, ok := v.(json.Unmarshaler); ok {
+		return unmarshaler.UnmarshalJSON(data)
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (stdJSONCodec) NewDecoder(r io.Reader) JSONDecoder {
+	return json.NewDecoder(r)
+}
+
+// jsonTokenDecoder is a JSONDecoder able to read JSON tokens
+type jsonTokenDecoder interface {
+	JSONDecoder
+	Token() (json.Token, error)
+}
+
+func (c *Client) jsonCodec() JSONCodec {
+	return codecOrDefault(c.config.JSONCodec)
+}
+
+func codecOrDefault(codec JSONCodec) JSONCodec {
+	if codec == nil {
+		return DefaultJSONCodec()
+	}
+	return codec
+}
+
+// unmarshalWithNumbers is codec.Unmarshal keeping numbers as json.Number when
+// the decoder of the codec supports it
+func unmarshalWithNumbers(codec JSONCodec, data []byte, v interface{}) error {
+	d := codec.NewDecoder(bytes.NewReader(data))
+	if n, ok := d.(interface{ UseNumber() }); ok {
+		n.UseNumber()
+	}
+	return d.Decode(v)
+}
@@ -0,0 +1,171 @@
+package meilisearch
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countingJSONCodec wraps DefaultJSONCodec and counts its calls
+type countingJSONCodec struct {
+	marshal, unmarshal, decoders int64
+}
+
+func (c *countingJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	atomic.AddInt64(&c.marshal, 1)
+	return DefaultJSONCodec().Marshal(v)
+}
+
+func (c *countingJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	atomic.AddInt64(&c.unmarshal, 1)
+	return DefaultJSONCodec().Unmarshal(data, v)
+}
+
+func (c *countingJSONCodec) NewDecoder(r io.Reader) JSONDecoder {
+	atomic.AddInt64(&c.decoders, 1)
+	return DefaultJSONCodec().NewDecoder(r)
+}
+
+func TestDefaultJSONCodec_Marshal(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "TestMarshalStruct", value: docTestBooks{BookID: 123, Title: "Pride <and> Prejudice", Tag: "Romance", Year: 1813}},
+		{name: "TestMarshalSlice", value: []docTest{{ID: "123", Name: "Pride and Prejudice"}}},
+		{name: "TestMarshalMap", value: map[string]interface{}{"id": 1, "tags": []string{"a", "b"}, "price": 9.5, "nil": nil}},
+		{name: "TestMarshalEasyjsonSearchRequest", value: &SearchRequest{Limit: 10, Filter: "year > 1900", AttributesToRetrieve: []string{"title"}}},
+		{name: "TestMarshalEasyjsonSettings", value: &Settings{RankingRules: []string{"words"}, DistinctAttribute: nil}},
+		{name: "TestMarshalRawMessage", value: json.RawMessage(`{"id":1}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := json.Marshal(tt.value)
+			require.NoError(t, err)
+			got, err := DefaultJSONCodec().Marshal(tt.value)
+			require.NoError(t, err)
+			require.JSONEq(t, string(want), string(got))
+		})
+	}
+}
+
+func TestDefaultJSONCodec_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		value interface{}
+	}{
+		{name: "TestUnmarshalStruct", data: `{"book_id":123,"title":"Pride and Prejudice","tag":"Romance","year":1813}`, value: &docTestBooks{}},
+		{name: "TestUnmarshalMaps", data: `[{"id":1,"price":9.5,"tags":["a"]},{"id":2}]`, value: &[]map[string]interface{}{}},
+		{name: "TestUnmarshalEasyjsonSearchResponse", data: `{"hits":[{"id":1}],"nbHits":1,"offset":0,"limit":20,"processingTimeMs":1,"query":"prince"}`, value: &SearchResponse{}},
+		{name: "TestUnmarshalEasyjsonUpdate", data: `{"status":"processed","updateId":1,"type":{"name":"DocumentsAddition","number":2}}`, value: &Update{}},
+		{name: "TestUnmarshalRawMessages", data: `[{"id":1},{"id":2}]`, value: &[]json.RawMessage{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := reflect.New(reflect.TypeOf(tt.value).Elem()).Interface()
+			require.NoError(t, json.Unmarshal([]byte(tt.data), want))
+			require.NoError(t, DefaultJSONCodec().Unmarshal([]byte(tt.data), tt.value))
+			require.Equal(t, want, tt.value)
+		})
+	}
+
+	err := DefaultJSONCodec().Unmarshal([]byte(`{"hits":`), &SearchResponse{})
+	require.Error(t, err)
+	err = DefaultJSONCodec().Unmarshal([]byte(`{"id":`), &map[string]interface{}{})
+	require.Error(t, err)
+}
+
+func TestDefaultJSONCodec_NewDecoder(t *testing.T) {
+	d := DefaultJSONCodec().NewDecoder(strings.NewReader(`{"id":"1"} {"id":"2"}`))
+	_, ok := d.(jsonTokenDecoder)
+	require.True(t, ok)
+
+	var documents []docTest
+	for d.More() {
+		var document docTest
+		require.NoError(t, d.Decode(&document))
+		documents = append(documents, document)
+	}
+	require.Equal(t, []docTest{{ID: "1"}, {ID: "2"}}, documents)
+}
+
+func TestClient_JSONCodec(t *testing.T) {
+	codec := &countingJSONCodec{}
+	c := NewClient(ClientConfig{
+		Host:      "http://localhost:7700",
+		APIKey:    masterKey,
+		JSONCodec: codec,
+	})
+	i := c.Index("jsoncodec")
+	t.Cleanup(cleanup(c))
+
+	books := []docTestBooks{
+		{BookID: 123, Title: "Pride and Prejudice", Tag: "Romance", Year: 1813},
+		{BookID: 456, Title: "Le Petit Prince", Tag: "Tale", Year: 1943},
+	}
+	update, err := i.AddDocuments(books)
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+
+	updates, err := i.AddDocumentsJSONFromReaderInBatches(strings.NewReader(`[{"book_id":1,"title":"Alice In Wonderland"}]`), 10)
+	require.NoError(t, err)
+	testWaitForPendingBatchUpdate(t, i, updates)
+
+	resp, err := i.Search("prince", &SearchRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Hits, 1)
+
+	require.NotZero(t, atomic.LoadInt64(&codec.marshal))
+	require.NotZero(t, atomic.LoadInt64(&codec.unmarshal))
+	require.Equal(t, int64(1), atomic.LoadInt64(&codec.decoders))
+}
+
+// floatJSONCodec decodes numbers as float64, its decoder has no UseNumber
+type floatJSONCodec struct {
+	countingJSONCodec
+}
+
+func (c *floatJSONCodec) NewDecoder(r io.Reader) JSONDecoder {
+	return struct{ JSONDecoder }{c.countingJSONCodec.NewDecoder(r)}
+}
+
+func Test_normalizeDocuments(t *testing.T) {
+	tests := []struct {
+		name  string
+		codec JSONCodec
+		want  interface{}
+	}{
+		{
+			name:  "TestNormalizeDocumentsUseNumber",
+			codec: &countingJSONCodec{},
+			want:  json.Number("9007199254740993"),
+		},
+		{
+			name:  "TestNormalizeDocumentsFloat",
+			codec: &floatJSONCodec{},
+			want:  float64(9007199254740992),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documents, err := normalizeDocuments(tt.codec, []map[string]interface{}{{"id": uint64(9007199254740993)}})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, documents[0]["id"])
+			_, ok := documentID(documents[0], "id")
+			require.True(t, ok)
+			require.NoError(t, validateDocuments(tt.codec, []map[string]interface{}{{"id": 1}}, "id"))
+
+			data, err := tt.codec.Marshal(documents[0])
+			require.NoError(t, err)
+			document, err := decodeDocument(tt.codec, data)
+			require.NoError(t, err)
+			require.Equal(t, documents[0], document)
+		})
+	}
+}
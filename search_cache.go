@@ -0,0 +1,331 @@
+package meilisearch
+
+import (
+	"container/list"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SearchCacheConfig configure the cache of the results of Index.Search,
+// SearchInto, SearchRaw and SearchWith, enabled by ClientConfig.SearchCache
+type SearchCacheConfig struct {
+
+	// TTL is the duration a cached response is served without asking the
+	// server. Default to 1 minute.
+	TTL time.Duration
+
+	// MaxEntries is the maximum number of cached responses, the least
+	// recently used are evicted first. Default to 1000.
+	MaxEntries int
+
+	// StaleWhileRevalidate is the duration after TTL during which an expired
+	// response is still served while it is refreshed in the background.
+	// Default to 0, expired responses are refreshed before being returned.
+	StaleWhileRevalidate time.Duration
+
+	// ServeStaleOnError returns an expired response, however old it is, when
+	// the server cannot be reached. Errors returned by Meilisearch are never
+	// hidden.
+	ServeStaleOnError bool
+}
+
+// searchCache is a LRU cache of search responses keyed by index and search
+// parameters. The hits are cached as raw JSON and decoded by every search, as
+// the hit type depends on the search method and callers may modify them. The
+// responses of an index are invalidated when a write request
+// on the index succeeds and when one of its updates is seen processed.
+//
+// Meilisearch applies writes asynchronously, a search between a write and
+// its processing would cache the old documents. The updates enqueued by
+// writes are recorded and the searches of their index bypass the cache until
+// they are seen finished by GetUpdateStatus, GetAllUpdateStatus or
+// WaitForPendingUpdate, until the index is deleted or, for updates never
+// polled, for TTL after they were enqueued.
+type searchCache struct {
+	config SearchCacheConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	// generations of the indexes, incremented by every invalidation so a
+	// response fetched before an invalidation is not stored after it
+	generations map[string]uint64
+	// pending are the enqueue times of the updates enqueued by the client
+	// and not yet seen finished, by index
+	pending map[string]map[int64]time.Time
+}
+
+type searchCacheEntry struct {
+	key        string
+	indexUID   string
+	result     *searchResult
+	storedAt   time.Time
+	refreshing bool
+}
+
+func newSearchCache(config *SearchCacheConfig) *searchCache {
+	if config == nil {
+		return nil
+	}
+	c := &searchCache{
+		config:      *config,
+		now:         time.Now,
+		entries:     map[string]*list.Element{},
+		lru:         list.New(),
+		generations: map[string]uint64{},
+		pending:     map[string]map[int64]time.Time{},
+	}
+	if c.config.TTL <= 0 {
+		c.config.TTL = time.Minute
+	}
+	if c.config.MaxEntries <= 0 {
+		c.config.MaxEntries = 1000
+	}
+	return c
+}
+
+// search returns the cached response of the search when there is one and
+// calls fetch otherwise
+func (c *searchCache) search(indexUID string, params map[string]interface{}, fetch func() (*searchResult, error)) (*searchResult, error) {
+	// encoding/json sorts the keys of maps, equal parameters give equal keys
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fetch()
+	}
+	key := indexUID + "\x00" + string(body)
+
+	c.mu.Lock()
+	c.expirePendingLocked(indexUID)
+	if len(c.pending[indexUID]) != 0 {
+		// The cache would hold documents about to change
+		c.mu.Unlock()
+		return fetch()
+	}
+	generation := c.generations[indexUID]
+	var stale *searchResult
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*searchCacheEntry)
+		age := c.now().Sub(entry.storedAt)
+		switch {
+		case age < c.config.TTL:
+			c.lru.MoveToFront(element)
+			c.mu.Unlock()
+			return entry.result.copy(), nil
+		case age < c.config.TTL+c.config.StaleWhileRevalidate:
+			c.lru.MoveToFront(element)
+			if !entry.refreshing {
+				entry.refreshing = true
+				go c.refresh(indexUID, key, generation, fetch)
+			}
+			c.mu.Unlock()
+			return entry.result.copy(), nil
+		}
+		stale = entry.result
+	}
+	c.mu.Unlock()
+
+	result, err := fetch()
+	if err != nil {
+		if stale != nil && c.config.ServeStaleOnError && isCommunicationError(err) {
+			return stale.copy(), nil
+		}
+		return nil, err
+	}
+	c.store(indexUID, key, generation, result)
+	return result.copy(), nil
+}
+
+func (c *searchCache) refresh(indexUID string, key string, generation uint64, fetch func() (*searchResult, error)) {
+	result, err := fetch()
+	if err == nil {
+		c.store(indexUID, key, generation, result)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*searchCacheEntry).refreshing = false
+	}
+}
+
+func (c *searchCache) store(indexUID string, key string, generation uint64, result *searchResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generations[indexUID] != generation {
+		return
+	}
+
+	entry := &searchCacheEntry{key: key, indexUID: indexUID, result: result, storedAt: c.now()}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.config.MaxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*searchCacheEntry).key)
+	}
+}
+
+// invalidate drops the cached responses of an index
+func (c *searchCache) invalidate(indexUID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidateLocked(indexUID)
+}
+
+// enqueued invalidates an index and bypasses the cache for it until the
+// update is finished
+func (c *searchCache) enqueued(indexUID string, updateID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Expire the updates of every index so pending does not grow with
+	// updates never polled
+	for uid := range c.pending {
+		c.expirePendingLocked(uid)
+	}
+	if c.pending[indexUID] == nil {
+		c.pending[indexUID] = map[int64]time.Time{}
+	}
+	c.pending[indexUID][updateID] = c.now()
+	c.invalidateLocked(indexUID)
+}
+
+// expirePendingLocked forgets the updates of an index enqueued more than TTL
+// ago, the cache is not bypassed forever for updates whose status is never
+// polled
+func (c *searchCache) expirePendingLocked(indexUID string) {
+	updates, ok := c.pending[indexUID]
+	if !ok {
+		return
+	}
+	now := c.now()
+	expired := false
+	for updateID, enqueuedAt := range updates {
+		if now.Sub(enqueuedAt) >= c.config.TTL {
+			delete(updates, updateID)
+			expired = true
+		}
+	}
+	if len(updates) == 0 {
+		delete(c.pending, indexUID)
+	}
+	if expired {
+		c.invalidateLocked(indexUID)
+	}
+}
+
+// finished invalidates an index once one of its updates is processed or
+// failed, and stops bypassing the cache for it
+func (c *searchCache) finished(indexUID string, updateID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending[indexUID], updateID)
+	if len(c.pending[indexUID]) == 0 {
+		delete(c.pending, indexUID)
+	}
+	c.invalidateLocked(indexUID)
+}
+
+// deleted forgets the pending updates of a deleted index
+func (c *searchCache) deleted(indexUID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending, indexUID)
+	c.invalidateLocked(indexUID)
+}
+
+func (c *searchCache) invalidateLocked(indexUID string) {
+	c.generations[indexUID]++
+	for key, element := range c.entries {
+		if element.Value.(*searchCacheEntry).indexUID == indexUID {
+			c.lru.Remove(element)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// searchCacheUpdateSeen stops bypassing the search cache of an index for an
+// update seen processed or failed
+func (c *Client) searchCacheUpdateSeen(indexUID string, update *Update) {
+	if c.searchCache == nil {
+		return
+	}
+	if update.Status == UpdateStatusProcessed || update.Status == UpdateStatusFailed {
+		c.searchCache.finished(indexUID, update.UpdateID)
+	}
+}
+
+// invalidateSearchCacheAfter drops the cached search responses of the index
+// targeted by a successful write request, and records the update it enqueued
+func (c *Client) invalidateSearchCacheAfter(req *internalRequest) {
+	if c.searchCache == nil || req.method == http.MethodGet {
+		return
+	}
+	path := strings.TrimPrefix(req.endpoint, "/indexes/")
+	if path == req.endpoint || path == "" {
+		return
+	}
+	parts := strings.SplitN(path, "/", 2)
+	switch {
+	case len(parts) == 2 && parts[1] == "search":
+	case len(parts) == 1 && req.method == http.MethodDelete:
+		c.searchCache.deleted(parts[0])
+	default:
+		if update, ok := req.withResponse.(*AsyncUpdateID); ok {
+			c.searchCache.enqueued(parts[0], update.UpdateID)
+		} else {
+			c.searchCache.invalidate(parts[0])
+		}
+	}
+}
+
+// searchResult is a cached search, the response without its hits and the
+// raw hits
+type searchResult struct {
+	resp *SearchResponse
+	hits []json.RawMessage
+}
+
+func (r *searchResult) copy() *searchResult {
+	resp := *r.resp
+	resp.FacetsDistribution = copyFacetsDistribution(r.resp.FacetsDistribution)
+	var hits []json.RawMessage
+	if r.hits != nil {
+		hits = make([]json.RawMessage, len(r.hits))
+		for j, hit := range r.hits {
+			hits[j] = append(json.RawMessage{}, hit...)
+		}
+	}
+	return &searchResult{resp: &resp, hits: hits}
+}
+
+func copyFacetsDistribution(facets map[string]map[string]int64) map[string]map[string]int64 {
+	if facets == nil {
+		return nil
+	}
+	copied := make(map[string]map[string]int64, len(facets))
+	for attribute, distribution := range facets {
+		if distribution == nil {
+			copied[attribute] = nil
+			continue
+		}
+		values := make(map[string]int64, len(distribution))
+		for value, count := range distribution {
+			values[value] = count
+		}
+		copied[attribute] = values
+	}
+	return copied
+}
+
+func isCommunicationError(err error) bool {
+	apiErr, ok := err.(*Error)
+	return ok && (apiErr.ErrCode == MeilisearchCommunicationError || apiErr.ErrCode == MeilisearchTimeoutError)
+}
@@ -0,0 +1,430 @@
+package meilisearch
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testSearchCacheClock struct {
+	now time.Time
+}
+
+func (c *testSearchCacheClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestSearchCache(config SearchCacheConfig) (*searchCache, *testSearchCacheClock) {
+	clock := &testSearchCacheClock{now: time.Unix(0, 0)}
+	cache := newSearchCache(&config)
+	cache.now = func() time.Time { return clock.now }
+	return cache, clock
+}
+
+// countingFetch returns responses whose NbHits is the number of calls
+func countingFetch(calls *int64, err *error) func() (*searchResult, error) {
+	return func() (*searchResult, error) {
+		n := atomic.AddInt64(calls, 1)
+		if err != nil && *err != nil {
+			return nil, *err
+		}
+		return &searchResult{resp: &SearchResponse{NbHits: n}, hits: []json.RawMessage{json.RawMessage(`"hit"`)}}, nil
+	}
+}
+
+func TestSearchCache_TTL(t *testing.T) {
+	cache, clock := newTestSearchCache(SearchCacheConfig{TTL: time.Second})
+	var calls int64
+	fetch := countingFetch(&calls, nil)
+	params := map[string]interface{}{"q": "prince", "limit": 5}
+
+	result, err := cache.search("books", params, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.resp.NbHits)
+
+	// Same parameters in another map, cached
+	result, err = cache.search("books", map[string]interface{}{"limit": 5, "q": "prince"}, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.resp.NbHits)
+
+	// Other parameters or index, not cached
+	_, err = cache.search("books", map[string]interface{}{"q": "prince", "limit": 6}, fetch)
+	require.NoError(t, err)
+	_, err = cache.search("movies", params, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), calls)
+
+	// Returned responses are copies
+	result.hits[0][1] = 'H'
+	result, err = cache.search("movies", params, fetch)
+	require.NoError(t, err)
+	require.Equal(t, []json.RawMessage{json.RawMessage(`"hit"`)}, result.hits)
+
+	clock.advance(time.Second)
+	result, err = cache.search("books", params, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), result.resp.NbHits)
+}
+
+func TestSearchCache_CopiesFacetsDistribution(t *testing.T) {
+	cache, _ := newTestSearchCache(SearchCacheConfig{})
+	fetch := func() (*searchResult, error) {
+		return &searchResult{resp: &SearchResponse{FacetsDistribution: map[string]map[string]int64{"tag": {"Tale": 2}}}}, nil
+	}
+	params := map[string]interface{}{"q": "prince"}
+
+	result, err := cache.search("books", params, fetch)
+	require.NoError(t, err)
+	result.resp.FacetsDistribution["tag"]["Tale"] = 10
+	result.resp.FacetsDistribution["year"] = map[string]int64{"1943": 1}
+
+	result, err = cache.search("books", params, fetch)
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]int64{"tag": {"Tale": 2}}, result.resp.FacetsDistribution)
+}
+
+func TestSearchCache_StaleWhileRevalidate(t *testing.T) {
+	cache, clock := newTestSearchCache(SearchCacheConfig{TTL: time.Second, StaleWhileRevalidate: time.Second})
+	var calls int64
+	fetch := countingFetch(&calls, nil)
+	params := map[string]interface{}{"q": "prince"}
+
+	_, err := cache.search("books", params, fetch)
+	require.NoError(t, err)
+
+	clock.advance(time.Second + time.Millisecond)
+	result, err := cache.search("books", params, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.resp.NbHits)
+	require.Eventually(t, func() bool {
+		result, err := cache.search("books", params, fetch)
+		return err == nil && result.resp.NbHits == 2
+	}, time.Second, time.Millisecond)
+	require.Equal(t, int64(2), atomic.LoadInt64(&calls))
+
+	// Past the stale window, refreshed before being returned
+	clock.advance(3 * time.Second)
+	result, err = cache.search("books", params, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), result.resp.NbHits)
+}
+
+func TestSearchCache_ServeStaleOnError(t *testing.T) {
+	tests := []struct {
+		name              string
+		serveStaleOnError bool
+		err               error
+		wantStale         bool
+	}{
+		{
+			name:              "TestSearchCacheServeStaleOnCommunicationError",
+			serveStaleOnError: true,
+			err:               &Error{ErrCode: MeilisearchCommunicationError},
+			wantStale:         true,
+		},
+		{
+			name:              "TestSearchCacheServeStaleOnTimeoutError",
+			serveStaleOnError: true,
+			err:               &Error{ErrCode: MeilisearchTimeoutError},
+			wantStale:         true,
+		},
+		{
+			name:              "TestSearchCacheNoStaleOnApiError",
+			serveStaleOnError: true,
+			err:               &Error{ErrCode: MeilisearchApiError, StatusCode: http.StatusBadRequest},
+		},
+		{
+			name: "TestSearchCacheNoStaleWhenDisabled",
+			err:  &Error{ErrCode: MeilisearchCommunicationError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, clock := newTestSearchCache(SearchCacheConfig{TTL: time.Second, ServeStaleOnError: tt.serveStaleOnError})
+			var (
+				calls    int64
+				fetchErr error
+			)
+			fetch := countingFetch(&calls, &fetchErr)
+			params := map[string]interface{}{"q": "prince"}
+
+			_, err := cache.search("books", params, fetch)
+			require.NoError(t, err)
+
+			clock.advance(time.Hour)
+			fetchErr = tt.err
+			result, err := cache.search("books", params, fetch)
+			if tt.wantStale {
+				require.NoError(t, err)
+				require.Equal(t, int64(1), result.resp.NbHits)
+			} else {
+				require.Equal(t, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestSearchCache_MaxEntries(t *testing.T) {
+	cache, _ := newTestSearchCache(SearchCacheConfig{MaxEntries: 2})
+	var calls int64
+	fetch := countingFetch(&calls, nil)
+
+	for _, q := range []string{"a", "b", "a", "c", "a", "b"} {
+		_, err := cache.search("books", map[string]interface{}{"q": q}, fetch)
+		require.NoError(t, err)
+	}
+	// "b" is evicted by "c" as "a" was used more recently
+	require.Equal(t, int64(4), calls)
+	require.Equal(t, 2, cache.lru.Len())
+}
+
+func TestSearchCache_Invalidate(t *testing.T) {
+	cache, _ := newTestSearchCache(SearchCacheConfig{})
+	var calls int64
+	fetch := countingFetch(&calls, nil)
+	params := map[string]interface{}{"q": "prince"}
+
+	_, err := cache.search("books", params, fetch)
+	require.NoError(t, err)
+	_, err = cache.search("movies", params, fetch)
+	require.NoError(t, err)
+
+	cache.invalidate("books")
+	result, err := cache.search("books", params, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), result.resp.NbHits)
+	result, err = cache.search("movies", params, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.resp.NbHits)
+
+	// A response fetched before an invalidation is not stored
+	_, err = cache.search("books", map[string]interface{}{"q": "hobbit"}, func() (*searchResult, error) {
+		cache.invalidate("books")
+		return fetch()
+	})
+	require.NoError(t, err)
+	result, err = cache.search("books", map[string]interface{}{"q": "hobbit"}, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), result.resp.NbHits)
+}
+
+func TestSearchCache_PendingUpdates(t *testing.T) {
+	c := NewClient(ClientConfig{SearchCache: &SearchCacheConfig{}})
+	var calls int64
+	fetch := countingFetch(&calls, nil)
+	params := map[string]interface{}{"q": "prince"}
+
+	_, err := c.searchCache.search("books", params, fetch)
+	require.NoError(t, err)
+
+	// Searches between a write and its processing are not cached
+	c.invalidateSearchCacheAfter(&internalRequest{
+		endpoint:     "/indexes/books/documents",
+		method:       http.MethodPost,
+		withResponse: &AsyncUpdateID{UpdateID: 7},
+	})
+	for j := 0; j < 2; j++ {
+		_, err = c.searchCache.search("books", params, fetch)
+		require.NoError(t, err)
+	}
+	require.Equal(t, int64(3), calls)
+
+	// Other updates of the index do not end the bypass
+	c.searchCacheUpdateSeen("books", &Update{UpdateID: 6, Status: UpdateStatusProcessed})
+	c.searchCacheUpdateSeen("books", &Update{UpdateID: 7, Status: UpdateStatusProcessing})
+	_, err = c.searchCache.search("books", params, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), calls)
+
+	c.searchCacheUpdateSeen("books", &Update{UpdateID: 7, Status: UpdateStatusProcessed})
+	for j := 0; j < 2; j++ {
+		result, err := c.searchCache.search("books", params, fetch)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), result.resp.NbHits)
+	}
+
+	// Deleting the index forgets its pending updates
+	c.invalidateSearchCacheAfter(&internalRequest{
+		endpoint:     "/indexes/books/documents",
+		method:       http.MethodPost,
+		withResponse: &AsyncUpdateID{UpdateID: 8},
+	})
+	c.invalidateSearchCacheAfter(&internalRequest{endpoint: "/indexes/books", method: http.MethodDelete})
+	for j := 0; j < 2; j++ {
+		_, err = c.searchCache.search("books", params, fetch)
+		require.NoError(t, err)
+	}
+	require.Equal(t, int64(6), calls)
+}
+
+func TestSearchCache_PendingUpdateNeverPolled(t *testing.T) {
+	cache, clock := newTestSearchCache(SearchCacheConfig{TTL: time.Minute})
+	var calls int64
+	fetch := countingFetch(&calls, nil)
+	params := map[string]interface{}{"q": "prince"}
+
+	cache.enqueued("books", 1)
+	_, err := cache.search("books", params, fetch)
+	require.NoError(t, err)
+	_, err = cache.search("books", params, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), calls)
+
+	// After TTL the update is forgotten and the cache is used again
+	clock.advance(time.Minute)
+	for j := 0; j < 2; j++ {
+		result, err := cache.search("books", params, fetch)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), result.resp.NbHits)
+	}
+	require.Empty(t, cache.pending)
+
+	// Updates of other indexes are expired too
+	cache.enqueued("movies", 2)
+	clock.advance(time.Minute)
+	cache.enqueued("books", 3)
+	require.Len(t, cache.pending, 1)
+}
+
+func TestClient_invalidateSearchCacheAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      internalRequest
+		wantMiss bool
+	}{
+		{
+			name:     "TestInvalidateAfterAddDocuments",
+			req:      internalRequest{endpoint: "/indexes/books/documents", method: http.MethodPost},
+			wantMiss: true,
+		},
+		{
+			name:     "TestInvalidateAfterDeleteIndex",
+			req:      internalRequest{endpoint: "/indexes/books", method: http.MethodDelete},
+			wantMiss: true,
+		},
+		{
+			name: "TestNoInvalidateAfterSearch",
+			req:  internalRequest{endpoint: "/indexes/books/search", method: http.MethodPost},
+		},
+		{
+			name: "TestNoInvalidateAfterGet",
+			req:  internalRequest{endpoint: "/indexes/books/documents", method: http.MethodGet},
+		},
+		{
+			name: "TestNoInvalidateAfterOtherIndex",
+			req:  internalRequest{endpoint: "/indexes/movies/documents", method: http.MethodPost},
+		},
+		{
+			name: "TestNoInvalidateAfterCreateIndex",
+			req:  internalRequest{endpoint: "/indexes", method: http.MethodPost},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(ClientConfig{SearchCache: &SearchCacheConfig{}})
+			var calls int64
+			fetch := countingFetch(&calls, nil)
+			params := map[string]interface{}{"q": "prince"}
+
+			_, err := c.searchCache.search("books", params, fetch)
+			require.NoError(t, err)
+			c.invalidateSearchCacheAfter(&tt.req)
+			_, err = c.searchCache.search("books", params, fetch)
+			require.NoError(t, err)
+			if tt.wantMiss {
+				require.Equal(t, int64(2), calls)
+			} else {
+				require.Equal(t, int64(1), calls)
+			}
+		})
+	}
+}
+
+func TestIndex_SearchCached(t *testing.T) {
+	c := NewClient(ClientConfig{
+		Host:        "http://localhost:7700",
+		APIKey:      masterKey,
+		SearchCache: &SearchCacheConfig{TTL: time.Hour},
+	})
+	SetUpBasicIndex()
+	i := c.Index("indexUID")
+	t.Cleanup(cleanup(c))
+
+	resp, err := i.Search("prince", &SearchRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.NbHits)
+
+	// Added through another client, the cached response is returned
+	update, err := defaultClient.Index("indexUID").AddDocuments([]map[string]interface{}{
+		{"book_id": 2, "title": "The Prince"},
+	})
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, defaultClient.Index("indexUID"), update)
+	resp, err = i.Search("prince", &SearchRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.NbHits)
+
+	// Written through the cached client, the cache is invalidated and
+	// searched before the update is processed, the cache is bypassed
+	update, err = i.AddDocuments([]map[string]interface{}{
+		{"book_id": 3, "title": "Prince Caspian"},
+	})
+	require.NoError(t, err)
+	_, err = i.Search("prince", &SearchRequest{})
+	require.NoError(t, err)
+	testWaitForPendingUpdate(t, i, update)
+	resp, err = i.Search("prince", &SearchRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), resp.NbHits)
+}
+
+func TestIndex_SearchCachedEntryPoints(t *testing.T) {
+	c := NewClient(ClientConfig{
+		Host:        "http://localhost:7700",
+		SearchCache: &SearchCacheConfig{TTL: time.Hour},
+	})
+	i := c.Index("books")
+
+	// Cached without a server, every search method reads the entry
+	params := searchPostRequestParams("prince", &SearchRequest{})
+	_, err := c.searchCache.search("books", params, func() (*searchResult, error) {
+		return &searchResult{
+			resp: &SearchResponse{NbHits: 1},
+			hits: []json.RawMessage{json.RawMessage(`{"book_id":9007199254740993,"title":"Le Petit Prince"}`)},
+		}, nil
+	})
+	require.NoError(t, err)
+
+	resp, err := i.Search("prince", &SearchRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.NbHits)
+	require.Equal(t, "Le Petit Prince", resp.Hits[0].(map[string]interface{})["title"])
+	// Modified hits are not shared with the cache
+	resp.Hits[0].(map[string]interface{})["title"] = "modified"
+	resp, err = i.Search("prince", &SearchRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "Le Petit Prince", resp.Hits[0].(map[string]interface{})["title"])
+
+	resp, err = i.SearchWith(NewSearch("prince"))
+	require.NoError(t, err)
+	require.Len(t, resp.Hits, 1)
+
+	var hits []struct {
+		BookID int64  `json:"book_id"`
+		Title  string `json:"title"`
+	}
+	resp, err = i.SearchInto("prince", &SearchRequest{}, &hits)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.NbHits)
+	require.Nil(t, resp.Hits)
+	require.Len(t, hits, 1)
+	require.Equal(t, int64(9007199254740993), hits[0].BookID)
+
+	_, raw, err := i.SearchRaw("prince", &SearchRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []json.RawMessage{json.RawMessage(`{"book_id":9007199254740993,"title":"Le Petit Prince"}`)}, raw)
+}
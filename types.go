@@ -12,8 +12,9 @@ import (
 
 // Client is a structure that give you the power for interacting with an high-level api with Meilisearch.
 type Client struct {
-	config     ClientConfig
-	httpClient *fasthttp.Client
+	config      ClientConfig
+	httpClient  *fasthttp.Client
+	searchCache *searchCache
 }
 
 // Index is the type that represent an index in Meilisearch
@@ -151,15 +152,15 @@ type SearchRequest struct {
 
 // SearchResponse is the response body for search method
 type SearchResponse struct {
-	Hits                  []interface{} `json:"hits"`
-	NbHits                int64         `json:"nbHits"`
-	Offset                int64         `json:"offset"`
-	Limit                 int64         `json:"limit"`
-	ExhaustiveNbHits      bool          `json:"exhaustiveNbHits"`
-	ProcessingTimeMs      int64         `json:"processingTimeMs"`
-	Query                 string        `json:"query"`
-	FacetsDistribution    interface{}   `json:"facetsDistribution,omitempty"`
-	ExhaustiveFacetsCount interface{}   `json:"exhaustiveFacetsCount,omitempty"`
+	Hits                  []interface{}               `json:"hits"`
+	NbHits                int64                       `json:"nbHits"`
+	Offset                int64                       `json:"offset"`
+	Limit                 int64                       `json:"limit"`
+	ExhaustiveNbHits      bool                        `json:"exhaustiveNbHits"`
+	ProcessingTimeMs      int64                       `json:"processingTimeMs"`
+	Query                 string                      `json:"query"`
+	FacetsDistribution    map[string]map[string]int64 `json:"facetsDistribution,omitempty"`
+	ExhaustiveFacetsCount bool                        `json:"exhaustiveFacetsCount,omitempty"`
 }
 
 // DocumentsRequest is the request body for list documents method
@@ -996,21 +996,45 @@ func easyjson6601e8cdDecodeGithubComMeilisearchMeilisearchGo6(in *jlexer.Lexer,
 		case "query":
 			out.Query = string(in.String())
 		case "facetsDistribution":
-			if m, ok := out.FacetsDistribution.(easyjson.Unmarshaler); ok {
-				m.UnmarshalEasyJSON(in)
-			} else if m, ok := out.FacetsDistribution.(json.Unmarshaler); ok {
-				_ = m.UnmarshalJSON(in.Raw())
+			if in.IsNull() {
+				in.Skip()
 			} else {
-				out.FacetsDistribution = in.Interface()
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.FacetsDistribution = make(map[string]map[string]int64)
+				} else {
+					out.FacetsDistribution = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v31 map[string]int64
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						in.Delim('{')
+						if !in.IsDelim('}') {
+							v31 = make(map[string]int64)
+						} else {
+							v31 = nil
+						}
+						for !in.IsDelim('}') {
+							key := string(in.String())
+							in.WantColon()
+							var v32 int64
+							v32 = int64(in.Int64())
+							(v31)[key] = v32
+							in.WantComma()
+						}
+						in.Delim('}')
+					}
+					(out.FacetsDistribution)[key] = v31
+					in.WantComma()
+				}
+				in.Delim('}')
 			}
 		case "exhaustiveFacetsCount":
-			if m, ok := out.ExhaustiveFacetsCount.(easyjson.Unmarshaler); ok {
-				m.UnmarshalEasyJSON(in)
-			} else if m, ok := out.ExhaustiveFacetsCount.(json.Unmarshaler); ok {
-				_ = m.UnmarshalJSON(in.Raw())
-			} else {
-				out.ExhaustiveFacetsCount = in.Interface()
-			}
+			out.ExhaustiveFacetsCount = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -1032,16 +1056,16 @@ func easyjson6601e8cdEncodeGithubComMeilisearchMeilisearchGo6(out *jwriter.Write
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v31, v32 := range in.Hits {
-				if v31 > 0 {
+			for v33, v34 := range in.Hits {
+				if v33 > 0 {
 					out.RawByte(',')
 				}
-				if m, ok := v32.(easyjson.Marshaler); ok {
+				if m, ok := v34.(easyjson.Marshaler); ok {
 					m.MarshalEasyJSON(out)
-				} else if m, ok := v32.(json.Marshaler); ok {
+				} else if m, ok := v34.(json.Marshaler); ok {
 					out.Raw(m.MarshalJSON())
 				} else {
-					out.Raw(json.Marshal(v32))
+					out.Raw(json.Marshal(v34))
 				}
 			}
 			out.RawByte(']')
@@ -1077,27 +1101,45 @@ func easyjson6601e8cdEncodeGithubComMeilisearchMeilisearchGo6(out *jwriter.Write
 		out.RawString(prefix)
 		out.String(string(in.Query))
 	}
-	if in.FacetsDistribution != nil {
+	if len(in.FacetsDistribution) != 0 {
 		const prefix string = ",\"facetsDistribution\":"
 		out.RawString(prefix)
-		if m, ok := in.FacetsDistribution.(easyjson.Marshaler); ok {
-			m.MarshalEasyJSON(out)
-		} else if m, ok := in.FacetsDistribution.(json.Marshaler); ok {
-			out.Raw(m.MarshalJSON())
-		} else {
-			out.Raw(json.Marshal(in.FacetsDistribution))
+		{
+			out.RawByte('{')
+			v35First := true
+			for v35Name, v35Value := range in.FacetsDistribution {
+				if v35First {
+					v35First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v35Name))
+				out.RawByte(':')
+				if v35Value == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+					out.RawString(`null`)
+				} else {
+					out.RawByte('{')
+					v36First := true
+					for v36Name, v36Value := range v35Value {
+						if v36First {
+							v36First = false
+						} else {
+							out.RawByte(',')
+						}
+						out.String(string(v36Name))
+						out.RawByte(':')
+						out.Int64(int64(v36Value))
+					}
+					out.RawByte('}')
+				}
+			}
+			out.RawByte('}')
 		}
 	}
-	if in.ExhaustiveFacetsCount != nil {
+	if in.ExhaustiveFacetsCount {
 		const prefix string = ",\"exhaustiveFacetsCount\":"
 		out.RawString(prefix)
-		if m, ok := in.ExhaustiveFacetsCount.(easyjson.Marshaler); ok {
-			m.MarshalEasyJSON(out)
-		} else if m, ok := in.ExhaustiveFacetsCount.(json.Marshaler); ok {
-			out.Raw(m.MarshalJSON())
-		} else {
-			out.Raw(json.Marshal(in.ExhaustiveFacetsCount))
-		}
+		out.Bool(bool(in.ExhaustiveFacetsCount))
 	}
 	out.RawByte('}')
 }
@@ -1164,9 +1206,9 @@ func easyjson6601e8cdDecodeGithubComMeilisearchMeilisearchGo7(in *jlexer.Lexer,
 					out.AttributesToRetrieve = (out.AttributesToRetrieve)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v33 string
-					v33 = string(in.String())
-					out.AttributesToRetrieve = append(out.AttributesToRetrieve, v33)
+					var v37 string
+					v37 = string(in.String())
+					out.AttributesToRetrieve = append(out.AttributesToRetrieve, v37)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1187,9 +1229,9 @@ func easyjson6601e8cdDecodeGithubComMeilisearchMeilisearchGo7(in *jlexer.Lexer,
 					out.AttributesToCrop = (out.AttributesToCrop)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v34 string
-					v34 = string(in.String())
-					out.AttributesToCrop = append(out.AttributesToCrop, v34)
+					var v38 string
+					v38 = string(in.String())
+					out.AttributesToCrop = append(out.AttributesToCrop, v38)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1212,9 +1254,9 @@ func easyjson6601e8cdDecodeGithubComMeilisearchMeilisearchGo7(in *jlexer.Lexer,
 					out.AttributesToHighlight = (out.AttributesToHighlight)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v35 string
-					v35 = string(in.String())
-					out.AttributesToHighlight = append(out.AttributesToHighlight, v35)
+					var v39 string
+					v39 = string(in.String())
+					out.AttributesToHighlight = append(out.AttributesToHighlight, v39)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1245,9 +1287,9 @@ func easyjson6601e8cdDecodeGithubComMeilisearchMeilisearchGo7(in *jlexer.Lexer,
 					out.FacetsDistribution = (out.FacetsDistribution)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v36 string
-					v36 = string(in.String())
-					out.FacetsDistribution = append(out.FacetsDistribution, v36)
+					var v40 string
+					v40 = string(in.String())
+					out.FacetsDistribution = append(out.FacetsDistribution, v40)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1270,9 +1312,9 @@ func easyjson6601e8cdDecodeGithubComMeilisearchMeilisearchGo7(in *jlexer.Lexer,
 					out.Sort = (out.Sort)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v37 string
-					v37 = string(in.String())
-					out.Sort = append(out.Sort, v37)
+					var v41 string
+					v41 = string(in.String())
+					out.Sort = append(out.Sort, v41)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1308,11 +1350,11 @@ func easyjson6601e8cdEncodeGithubComMeilisearchMeilisearchGo7(out *jwriter.Write
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v38, v39 := range in.AttributesToRetrieve {
-				if v38 > 0 {
+			for v42, v43 := range in.AttributesToRetrieve {
+				if v42 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v39))
+				out.String(string(v43))
 			}
 			out.RawByte(']')
 		}
@@ -1324,11 +1366,11 @@ func easyjson6601e8cdEncodeGithubComMeilisearchMeilisearchGo7(out *jwriter.Write
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v40, v41 := range in.AttributesToCrop {
-				if v40 > 0 {
+			for v44, v45 := range in.AttributesToCrop {
+				if v44 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v41))
+				out.String(string(v45))
 			}
 			out.RawByte(']')
 		}
@@ -1345,11 +1387,11 @@ func easyjson6601e8cdEncodeGithubComMeilisearchMeilisearchGo7(out *jwriter.Write
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v42, v43 := range in.AttributesToHighlight {
-				if v42 > 0 {
+			for v46, v47 := range in.AttributesToHighlight {
+				if v46 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v43))
+				out.String(string(v47))
 			}
 			out.RawByte(']')
 		}
@@ -1377,11 +1419,11 @@ func easyjson6601e8cdEncodeGithubComMeilisearchMeilisearchGo7(out *jwriter.Write
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v44, v45 := range in.FacetsDistribution {
-				if v44 > 0 {
+			for v48, v49 := range in.FacetsDistribution {
+				if v48 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v45))
+				out.String(string(v49))
 			}
 			out.RawByte(']')
 		}
@@ -1398,11 +1440,11 @@ func easyjson6601e8cdEncodeGithubComMeilisearchMeilisearchGo7(out *jwriter.Write
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v46, v47 := range in.Sort {
-				if v46 > 0 {
+			for v50, v51 := range in.Sort {
+				if v50 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v47))
+				out.String(string(v51))
 			}
 			out.RawByte(']')
 		}
@@ -1799,9 +1841,9 @@ func easyjson6601e8cdDecodeGithubComMeilisearchMeilisearchGo12(in *jlexer.Lexer,
 					out.AttributesToRetrieve = (out.AttributesToRetrieve)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v48 string
-					v48 = string(in.String())
-					out.AttributesToRetrieve = append(out.AttributesToRetrieve, v48)
+					var v52 string
+					v52 = string(in.String())
+					out.AttributesToRetrieve = append(out.AttributesToRetrieve, v52)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1846,11 +1888,11 @@ func easyjson6601e8cdEncodeGithubComMeilisearchMeilisearchGo12(out *jwriter.Writ
 		}
 		{
 			out.RawByte('[')
-			for v49, v50 := range in.AttributesToRetrieve {
-				if v49 > 0 {
+			for v53, v54 := range in.AttributesToRetrieve {
+				if v53 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v50))
+				out.String(string(v54))
 			}
 			out.RawByte(']')
 		}